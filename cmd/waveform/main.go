@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/goedelsoup/waveform/internal/collector"
 	"github.com/goedelsoup/waveform/internal/config"
 	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/goedelsoup/waveform/internal/harness"
@@ -24,6 +25,8 @@ var (
 	lcovOutput    string
 	summaryOutput string
 	verbose       bool
+	updateGolden  bool
+	parallel      int
 )
 
 func main() {
@@ -45,6 +48,8 @@ pipelines transform data correctly.`,
 	rootCmd.Flags().StringVarP(&lcovOutput, "lcov-output", "l", "", "LCOV output file path")
 	rootCmd.Flags().StringVarP(&summaryOutput, "summary-output", "s", "", "Summary output file path")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose logging")
+	rootCmd.Flags().BoolVar(&updateGolden, "update-golden", false, "Rewrite golden snapshots from the current output")
+	rootCmd.Flags().IntVar(&parallel, "parallel", 0, "Number of contracts to run concurrently (overrides runner.parallel)")
 
 	// Mark required flags
 	if err := rootCmd.MarkFlagRequired("contracts"); err != nil {
@@ -145,6 +150,29 @@ func runTests(cmd *cobra.Command, args []string) error {
 	mode := harness.TestMode(testMode)
 	harness := harness.NewTestHarness(mode, collectorConfig)
 	harness.SetLogger(logger)
+	harness.SetProcessorFactory(collector.NewProcessorFactory(logger))
+	harness.SetUpdateGolden(updateGolden)
+
+	// The --parallel flag overrides the runner configuration
+	workers := runnerConfig.Runner.Parallel
+	if cmd.Flags().Changed("parallel") {
+		workers = parallel
+	}
+	harness.SetParallel(workers)
+
+	timeout, err := runnerConfig.ContractTimeout()
+	if err != nil {
+		return fmt.Errorf("invalid runner configuration: %w", err)
+	}
+	harness.SetTimeout(timeout)
+
+	retryPolicy, err := runnerConfig.Global.Retry.Policy()
+	if err != nil {
+		return fmt.Errorf("invalid runner configuration: global.retry: %w", err)
+	}
+	harness.SetRetryPolicy(retryPolicy)
+	harness.SetFailFast(runnerConfig.Global.FailFast)
+	harness.SetPipelineEnricher(runnerConfig.PipelineEnricher(configPath))
 
 	// Run tests
 	logger.Info("Running tests", zap.String("mode", string(mode)))
@@ -226,12 +254,14 @@ func runTests(cmd *cobra.Command, args []string) error {
 		logger.Info("Tests completed with failures",
 			zap.Int("total", results.TotalTests),
 			zap.Int("passed", results.PassedTests),
-			zap.Int("failed", results.FailedTests))
+			zap.Int("failed", results.FailedTests),
+			zap.Int("not_run", results.NotRunTests))
 		os.Exit(1)
 	}
 
 	logger.Info("All tests passed",
 		zap.Int("total", results.TotalTests),
+		zap.Int("skipped", results.SkippedTests),
 		zap.Duration("duration", results.Duration))
 	return nil
 }
@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -131,7 +132,7 @@ func main() {
 
 	// Process data through collector
 	logger.Info("Processing data through collector")
-	output, err := collectorService.ProcessData(inputData)
+	output, err := collectorService.ProcessData(context.Background(), inputData)
 	if err != nil {
 		logger.Fatal("Failed to process data", zap.Error(err))
 	}
@@ -29,6 +29,7 @@ func main() {
 			Metadata: map[string]string{
 				"processing_type": "validation",
 			},
+			Priority: 5,
 		},
 		{
 			ID:          "metric-user-prod",
@@ -231,4 +232,13 @@ func main() {
 
 	fmt.Printf("Best match: %s (%s)\n", bestMatch.Name, bestMatch.ID)
 	fmt.Printf("Description: %s\n", bestMatch.Description)
+
+	ranked, err := selectorService.RankPipelines(selectors6)
+	if err != nil {
+		log.Fatalf("Error ranking pipelines: %v", err)
+	}
+	fmt.Println("Ranking:")
+	for _, match := range ranked {
+		fmt.Printf("- %s\n", match)
+	}
 }
@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// BatchProcessor implements the batch processor. Data larger than send_batch_max_size
+// is split into batches of at most that many spans, data points or log records; a
+// metric is never split, so a batch may exceed the limit by one metric's data points.
+// send_batch_size only decides when the collector flushes a batch, and a contract's
+// input arrives as one request that is flushed whole, so like the collector's own
+// processor it never splits data by itself.
+type BatchProcessor struct {
+	name     string
+	config   interface{}
+	logger   *zap.Logger
+	sendSize int // send_batch_size
+	maxSize  int // send_batch_max_size, 0 for no limit
+}
+
+// defaultSendBatchSize is the collector's default send_batch_size
+const defaultSendBatchSize = 8192
+
+// NewBatchProcessor creates a new batch processor, rejecting the sizes the collector rejects
+func NewBatchProcessor(name string, config interface{}, logger *zap.Logger) (*BatchProcessor, error) {
+	processor := &BatchProcessor{
+		name:     name,
+		config:   config,
+		logger:   logger,
+		sendSize: defaultSendBatchSize,
+	}
+
+	if configMap, ok := config.(map[string]interface{}); ok {
+		for _, setting := range []struct {
+			key   string
+			value *int
+		}{
+			{"send_batch_size", &processor.sendSize},
+			{"send_batch_max_size", &processor.maxSize},
+		} {
+			raw, exists := configMap[setting.key]
+			if !exists {
+				continue
+			}
+			size, err := batchSize(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", setting.key, err)
+			}
+			*setting.value = size
+		}
+	}
+
+	if processor.maxSize > 0 && processor.maxSize < processor.sendSize {
+		return nil, fmt.Errorf("send_batch_max_size (%d) must be greater or equal to send_batch_size (%d)",
+			processor.maxSize, processor.sendSize)
+	}
+	return processor, nil
+}
+
+// batchSize converts a configured batch size to a non-negative int
+func batchSize(raw interface{}) (int, error) {
+	var size int
+	switch value := raw.(type) {
+	case int:
+		size = value
+	case int64:
+		size = int(value)
+	case uint64:
+		size = int(value)
+	case float64:
+		if value != float64(int(value)) {
+			return 0, fmt.Errorf("must be a whole number, got %v", value)
+		}
+		size = int(value)
+	default:
+		return 0, fmt.Errorf("must be a number, got %v", raw)
+	}
+	if size < 0 {
+		return 0, fmt.Errorf("must not be negative, got %d", size)
+	}
+	return size, nil
+}
+
+// Start implements component.StartFunc
+func (b *BatchProcessor) Start(ctx context.Context, host component.Host) error {
+	b.logger.Debug("Batch processor started", zap.String("name", b.name))
+	return nil
+}
+
+// Shutdown implements component.ShutdownFunc
+func (b *BatchProcessor) Shutdown(ctx context.Context) error {
+	b.logger.Debug("Batch processor shutdown", zap.String("name", b.name))
+	return nil
+}
+
+// BatchTraces splits traces into batches of at most send_batch_max_size spans
+func (b *BatchProcessor) BatchTraces(ctx context.Context, traces ptrace.Traces) ([]ptrace.Traces, error) {
+	var batches []ptrace.Traces
+	var batch ptrace.Traces
+	size := 0
+
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		var target ptrace.ResourceSpans
+		resourceOpen := false
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			scopeSpans := resourceSpans.ScopeSpans().At(j)
+			var spans ptrace.SpanSlice
+			scopeOpen := false
+			for k := 0; k < scopeSpans.Spans().Len(); k++ {
+				if len(batches) == 0 || (b.maxSize > 0 && size == b.maxSize) {
+					batch = ptrace.NewTraces()
+					batches = append(batches, batch)
+					size = 0
+					resourceOpen = false
+					scopeOpen = false
+				}
+				if !resourceOpen {
+					// Later scopes of the same resource join it while the batch has room
+					target = batch.ResourceSpans().AppendEmpty()
+					resourceSpans.Resource().CopyTo(target.Resource())
+					target.SetSchemaUrl(resourceSpans.SchemaUrl())
+					resourceOpen = true
+				}
+				if !scopeOpen {
+					scope := target.ScopeSpans().AppendEmpty()
+					scopeSpans.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(scopeSpans.SchemaUrl())
+					spans = scope.Spans()
+					scopeOpen = true
+				}
+				scopeSpans.Spans().At(k).CopyTo(spans.AppendEmpty())
+				size++
+			}
+		}
+	}
+
+	if len(batches) == 0 {
+		// Nothing to split, such as resources without any items
+		batch = ptrace.NewTraces()
+		traces.CopyTo(batch)
+		batches = append(batches, batch)
+	}
+
+	b.logger.Debug("Batch processor batched traces", zap.String("name", b.name), zap.Int("batches", len(batches)))
+	return batches, nil
+}
+
+// BatchMetrics splits metrics into batches of at most send_batch_max_size data points
+func (b *BatchProcessor) BatchMetrics(ctx context.Context, metrics pmetric.Metrics) ([]pmetric.Metrics, error) {
+	var batches []pmetric.Metrics
+	var batch pmetric.Metrics
+	size := 0
+
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		var target pmetric.ResourceMetrics
+		resourceOpen := false
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			scopeMetrics := resourceMetrics.ScopeMetrics().At(j)
+			var slice pmetric.MetricSlice
+			scopeOpen := false
+			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
+				metric := scopeMetrics.Metrics().At(k)
+				points := dataPointCount(metric)
+				if len(batches) == 0 || (b.maxSize > 0 && size > 0 && size+points > b.maxSize) {
+					batch = pmetric.NewMetrics()
+					batches = append(batches, batch)
+					size = 0
+					resourceOpen = false
+					scopeOpen = false
+				}
+				if !resourceOpen {
+					// Later scopes of the same resource join it while the batch has room
+					target = batch.ResourceMetrics().AppendEmpty()
+					resourceMetrics.Resource().CopyTo(target.Resource())
+					target.SetSchemaUrl(resourceMetrics.SchemaUrl())
+					resourceOpen = true
+				}
+				if !scopeOpen {
+					scope := target.ScopeMetrics().AppendEmpty()
+					scopeMetrics.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(scopeMetrics.SchemaUrl())
+					slice = scope.Metrics()
+					scopeOpen = true
+				}
+				metric.CopyTo(slice.AppendEmpty())
+				size += points
+			}
+		}
+	}
+
+	if len(batches) == 0 {
+		// Nothing to split, such as resources without any items
+		batch = pmetric.NewMetrics()
+		metrics.CopyTo(batch)
+		batches = append(batches, batch)
+	}
+
+	b.logger.Debug("Batch processor batched metrics", zap.String("name", b.name), zap.Int("batches", len(batches)))
+	return batches, nil
+}
+
+// BatchLogs splits logs into batches of at most send_batch_max_size log records
+func (b *BatchProcessor) BatchLogs(ctx context.Context, logs plog.Logs) ([]plog.Logs, error) {
+	var batches []plog.Logs
+	var batch plog.Logs
+	size := 0
+
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		var target plog.ResourceLogs
+		resourceOpen := false
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			var records plog.LogRecordSlice
+			scopeOpen := false
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				if len(batches) == 0 || (b.maxSize > 0 && size == b.maxSize) {
+					batch = plog.NewLogs()
+					batches = append(batches, batch)
+					size = 0
+					resourceOpen = false
+					scopeOpen = false
+				}
+				if !resourceOpen {
+					// Later scopes of the same resource join it while the batch has room
+					target = batch.ResourceLogs().AppendEmpty()
+					resourceLogs.Resource().CopyTo(target.Resource())
+					target.SetSchemaUrl(resourceLogs.SchemaUrl())
+					resourceOpen = true
+				}
+				if !scopeOpen {
+					scope := target.ScopeLogs().AppendEmpty()
+					scopeLogs.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(scopeLogs.SchemaUrl())
+					records = scope.LogRecords()
+					scopeOpen = true
+				}
+				scopeLogs.LogRecords().At(k).CopyTo(records.AppendEmpty())
+				size++
+			}
+		}
+	}
+
+	if len(batches) == 0 {
+		// Nothing to split, such as resources without any items
+		batch = plog.NewLogs()
+		logs.CopyTo(batch)
+		batches = append(batches, batch)
+	}
+
+	b.logger.Debug("Batch processor batched logs", zap.String("name", b.name), zap.Int("batches", len(batches)))
+	return batches, nil
+}
+
+// dataPointCount returns the number of data points of a metric
+func dataPointCount(metric pmetric.Metric) int {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return metric.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return metric.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return metric.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return metric.Summary().DataPoints().Len()
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"github.com/goedelsoup/waveform/internal/harness"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func TestBatchProcessor_SplitsBySendBatchMaxSize(t *testing.T) {
+	processor, err := NewBatchProcessor("batch", map[string]interface{}{"send_batch_size": 2, "send_batch_max_size": 2}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create batch processor: %v", err)
+	}
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := resourceSpans.ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		spans.AppendEmpty().SetName(name)
+	}
+
+	batches, err := processor.BatchTraces(context.Background(), traces)
+	if err != nil {
+		t.Fatalf("Failed to batch traces: %v", err)
+	}
+	if len(batches) != 3 {
+		t.Fatalf("Expected 3 batches, got %d", len(batches))
+	}
+	for i, want := range []int{2, 2, 1} {
+		if got := batches[i].SpanCount(); got != want {
+			t.Errorf("Expected batch %d to have %d spans, got %d", i, want, got)
+		}
+		if name, _ := batches[i].ResourceSpans().At(0).Resource().Attributes().Get("service.name"); name.Str() != "checkout" {
+			t.Errorf("Expected batch %d to keep its resource, got %q", i, name.Str())
+		}
+	}
+	if traces.SpanCount() != 5 {
+		t.Errorf("Expected the input to be left unchanged, got %d spans", traces.SpanCount())
+	}
+}
+
+func TestBatchProcessor_SendBatchSize(t *testing.T) {
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 5; i++ {
+		records.AppendEmpty()
+	}
+
+	// send_batch_size alone flushes the whole request, as the collector does
+	processor, err := NewBatchProcessor("batch", map[string]interface{}{"send_batch_size": 2}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create batch processor: %v", err)
+	}
+	batches, err := processor.BatchLogs(context.Background(), logs)
+	if err != nil {
+		t.Fatalf("Failed to batch logs: %v", err)
+	}
+	if len(batches) != 1 || batches[0].LogRecordCount() != 5 {
+		t.Errorf("Expected one batch of 5 log records, got %d batches", len(batches))
+	}
+
+	for _, tt := range []struct {
+		config map[string]interface{}
+		want   string
+	}{
+		{map[string]interface{}{"send_batch_max_size": 2}, "send_batch_max_size (2) must be greater or equal to send_batch_size (8192)"},
+		{map[string]interface{}{"send_batch_size": 10, "send_batch_max_size": 5}, "send_batch_max_size (5) must be greater or equal to send_batch_size (10)"},
+		{map[string]interface{}{"send_batch_size": -1}, "send_batch_size: must not be negative, got -1"},
+		{map[string]interface{}{"send_batch_size": "big"}, "send_batch_size: must be a number, got big"},
+	} {
+		if _, err := NewBatchProcessor("batch", tt.config, zap.NewNop()); err == nil || err.Error() != tt.want {
+			t.Errorf("NewBatchProcessor(%v): expected error %q, got %v", tt.config, tt.want, err)
+		}
+	}
+}
+
+func TestBatchProcessor_GroupsScopesUnderTheirResource(t *testing.T) {
+	processor, err := NewBatchProcessor("batch", map[string]interface{}{"send_batch_size": 3, "send_batch_max_size": 3}, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to create batch processor: %v", err)
+	}
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	for _, scopeName := range []string{"http", "db"} {
+		scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+		scopeSpans.Scope().SetName(scopeName)
+		scopeSpans.Spans().AppendEmpty().SetName(scopeName + "-1")
+		scopeSpans.Spans().AppendEmpty().SetName(scopeName + "-2")
+	}
+
+	batches, err := processor.BatchTraces(context.Background(), traces)
+	if err != nil {
+		t.Fatalf("Failed to batch traces: %v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("Expected 2 batches, got %d", len(batches))
+	}
+	first := batches[0]
+	if first.ResourceSpans().Len() != 1 || first.ResourceSpans().At(0).ScopeSpans().Len() != 2 {
+		t.Errorf("Expected both scopes of the first batch under one resource, got %d resources", first.ResourceSpans().Len())
+	}
+	second := batches[1]
+	if second.ResourceSpans().Len() != 1 || second.ResourceSpans().At(0).ScopeSpans().Len() != 1 ||
+		second.ResourceSpans().At(0).ScopeSpans().At(0).Scope().Name() != "db" {
+		t.Errorf("Expected the second batch to continue the db scope, got %d resources", second.ResourceSpans().Len())
+	}
+}
+
+func TestEngine_RetainsEveryBatch(t *testing.T) {
+	config := harness.CollectorConfig{
+		Processors: map[string]interface{}{
+			"batch": map[string]interface{}{"send_batch_size": 2, "send_batch_max_size": 2},
+		},
+		Service: map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"logs": map[string]interface{}{
+					"processors": []interface{}{"batch"},
+				},
+			},
+		},
+	}
+
+	input := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	records := input.Logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range []string{"one", "two", "three"} {
+		records.AppendEmpty().Body().SetStr(body)
+	}
+
+	processors, err := harness.BuildProcessors(config, NewProcessorFactory(zap.NewNop()))
+	if err != nil {
+		t.Fatalf("Failed to build processors: %v", err)
+	}
+	output, err := harness.NewEngine(config, processors, zap.NewNop()).Process(context.Background(), "", input)
+	if err != nil {
+		t.Fatalf("Failed to process data: %v", err)
+	}
+
+	if got := output.Logs.LogRecordCount(); got != 3 {
+		t.Errorf("Expected every batch to be merged into the output, got %d log records", got)
+	}
+	if len(output.Batches) != 2 || output.Batches[0].Items != 2 || output.Batches[1].Items != 1 {
+		t.Errorf("Expected logs batches of 2 and 1 records, got %+v", output.Batches)
+	}
+	if output.Batches[0].Signal != contract.SignalTypeLogs {
+		t.Errorf("Expected logs batches, got %s", output.Batches[0].Signal)
+	}
+}
@@ -232,52 +232,6 @@ func (f *FilterProcessor) ProcessLogs(ctx context.Context, logs plog.Logs) error
 	return nil
 }
 
-// BatchProcessor implements the batch processor
-type BatchProcessor struct {
-	name   string
-	config interface{}
-	logger *zap.Logger
-}
-
-// NewBatchProcessor creates a new batch processor
-func NewBatchProcessor(name string, config interface{}, logger *zap.Logger) *BatchProcessor {
-	return &BatchProcessor{
-		name:   name,
-		config: config,
-		logger: logger,
-	}
-}
-
-// Start implements component.StartFunc
-func (b *BatchProcessor) Start(ctx context.Context, host component.Host) error {
-	b.logger.Debug("Batch processor started", zap.String("name", b.name))
-	return nil
-}
-
-// Shutdown implements component.ShutdownFunc
-func (b *BatchProcessor) Shutdown(ctx context.Context) error {
-	b.logger.Debug("Batch processor shutdown", zap.String("name", b.name))
-	return nil
-}
-
-// ProcessTraces implements TraceProcessor
-func (b *BatchProcessor) ProcessTraces(ctx context.Context, traces ptrace.Traces) error {
-	b.logger.Debug("Batch processor processing traces", zap.String("name", b.name))
-	return nil
-}
-
-// ProcessMetrics implements MetricProcessor
-func (b *BatchProcessor) ProcessMetrics(ctx context.Context, metrics pmetric.Metrics) error {
-	b.logger.Debug("Batch processor processing metrics", zap.String("name", b.name))
-	return nil
-}
-
-// ProcessLogs implements LogProcessor
-func (b *BatchProcessor) ProcessLogs(ctx context.Context, logs plog.Logs) error {
-	b.logger.Debug("Batch processor processing logs", zap.String("name", b.name))
-	return nil
-}
-
 // MemoryLimiterProcessor implements the memory limiter processor
 type MemoryLimiterProcessor struct {
 	name   string
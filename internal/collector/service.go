@@ -10,26 +10,17 @@ import (
 	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/goedelsoup/waveform/internal/harness"
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/pdata/plog"
-	"go.opentelemetry.io/collector/pdata/pmetric"
-	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
 // TraceProcessor defines the interface for trace processing
-type TraceProcessor interface {
-	ProcessTraces(ctx context.Context, traces ptrace.Traces) error
-}
+type TraceProcessor = harness.TracesProcessor
 
 // MetricProcessor defines the interface for metric processing
-type MetricProcessor interface {
-	ProcessMetrics(ctx context.Context, metrics pmetric.Metrics) error
-}
+type MetricProcessor = harness.MetricsProcessor
 
 // LogProcessor defines the interface for log processing
-type LogProcessor interface {
-	ProcessLogs(ctx context.Context, logs plog.Logs) error
-}
+type LogProcessor = harness.LogsProcessor
 
 // Service represents a running OpenTelemetry collector service
 type Service struct {
@@ -38,6 +29,7 @@ type Service struct {
 	receivers  map[string]component.Component
 	processors map[string]component.Component
 	exporters  map[string]component.Component
+	pipeline   string // Name of the pipeline under test
 	ctx        context.Context
 	cancel     context.CancelFunc
 }
@@ -90,53 +82,26 @@ func (s *Service) Stop() error {
 	return nil
 }
 
-// ProcessData processes telemetry data through the configured pipeline
-func (s *Service) ProcessData(input interface{}) (interface{}, error) {
+// SetPipeline selects the pipeline that ProcessData runs each signal through, by full
+// ID (traces/checkout) or bare name (checkout)
+func (s *Service) SetPipeline(name string) {
+	s.pipeline = name
+}
+
+// ProcessData processes telemetry data through the configured pipeline, running each
+// signal through its own pipeline's processors in order until ctx is done
+func (s *Service) ProcessData(ctx context.Context, input interface{}) (interface{}, error) {
 	s.logger.Debug("Processing telemetry data through collector")
 
 	// Convert input to OpenTelemetryData if possible
-	var data contract.OpenTelemetryData
-	if inputData, ok := input.(contract.OpenTelemetryData); ok {
-		data = inputData
-	} else {
+	data, ok := input.(contract.OpenTelemetryData)
+	if !ok {
 		// If conversion fails, return input unchanged
 		return input, nil
 	}
 
-	// Process traces through processors
-	if data.Traces.ResourceSpans().Len() > 0 {
-		for _, processor := range s.processors {
-			if traceProcessor, ok := processor.(TraceProcessor); ok {
-				if err := traceProcessor.ProcessTraces(s.ctx, data.Traces); err != nil {
-					s.logger.Error("Failed to process traces", zap.Error(err))
-				}
-			}
-		}
-	}
-
-	// Process metrics through processors
-	if data.Metrics.ResourceMetrics().Len() > 0 {
-		for _, processor := range s.processors {
-			if metricProcessor, ok := processor.(MetricProcessor); ok {
-				if err := metricProcessor.ProcessMetrics(s.ctx, data.Metrics); err != nil {
-					s.logger.Error("Failed to process metrics", zap.Error(err))
-				}
-			}
-		}
-	}
-
-	// Process logs through processors
-	if data.Logs.ResourceLogs().Len() > 0 {
-		for _, processor := range s.processors {
-			if logProcessor, ok := processor.(LogProcessor); ok {
-				if err := logProcessor.ProcessLogs(s.ctx, data.Logs); err != nil {
-					s.logger.Error("Failed to process logs", zap.Error(err))
-				}
-			}
-		}
-	}
-
-	return data, nil
+	engine := harness.NewEngine(s.config, s.processors, s.logger)
+	return engine.Process(ctx, s.pipeline, data)
 }
 
 // initializeComponents initializes all collector components
@@ -261,34 +226,54 @@ func (s *Service) initializeReceiver(name string, config interface{}) error {
 func (s *Service) initializeProcessor(name string, config interface{}) error {
 	s.logger.Debug("Initializing processor", zap.String("name", name))
 
-	// Create processor based on type
-	var processor component.Component
-	switch name {
-	case "transform":
-		processor = NewEnhancedTransformProcessor(name, config, s.logger)
-	case "attributes":
-		processor = NewEnhancedAttributesProcessor(name, config, s.logger)
-	case "filter":
-		processor = NewEnhancedFilterProcessor(name, config, s.logger)
-	case "batch":
-		processor = NewBatchProcessor(name, config, s.logger)
-	case "memory_limiter":
-		processor = NewMemoryLimiterProcessor(name, config, s.logger)
-	case "resource":
-		processor = NewResourceProcessor(name, config, s.logger)
-	default:
-		s.logger.Warn("Unknown processor type, using mock", zap.String("name", name))
-		processor = &MockProcessor{
-			name:   name,
-			config: config,
-			logger: s.logger,
-		}
+	id, err := harness.ParseComponentID(name)
+	if err != nil {
+		return err
+	}
+
+	processor, err := NewProcessorFactory(s.logger)(id, config)
+	if err != nil {
+		return err
 	}
 
 	s.processors[name] = processor
 	return nil
 }
 
+// NewProcessorFactory returns the factory that creates processor components by type,
+// so attributes/redact is an attributes processor. The collector service and the test
+// harness's simulation both build their processors with it.
+func NewProcessorFactory(logger *zap.Logger) harness.ProcessorFactory {
+	return func(id harness.ComponentID, config interface{}) (component.Component, error) {
+		name := id.String()
+		switch id.Type {
+		case "transform":
+			return NewEnhancedTransformProcessor(name, config, logger), nil
+		case "attributes":
+			return NewEnhancedAttributesProcessor(name, config, logger), nil
+		case "filter":
+			return NewEnhancedFilterProcessor(name, config, logger), nil
+		case "batch":
+			processor, err := NewBatchProcessor(name, config, logger)
+			if err != nil {
+				return nil, err
+			}
+			return processor, nil
+		case "memory_limiter":
+			return NewMemoryLimiterProcessor(name, config, logger), nil
+		case "resource":
+			return NewResourceProcessor(name, config, logger), nil
+		default:
+			logger.Warn("Unknown processor type, using mock", zap.String("name", name))
+			return &MockProcessor{
+				name:   name,
+				config: config,
+				logger: logger,
+			}, nil
+		}
+	}
+}
+
 // initializeExporter initializes an exporter component
 func (s *Service) initializeExporter(name string, config interface{}) error {
 	s.logger.Debug("Initializing exporter", zap.String("name", name))
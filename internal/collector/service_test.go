@@ -4,9 +4,14 @@
 package collector
 
 import (
+	"context"
 	"testing"
 
+	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/goedelsoup/waveform/internal/harness"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
@@ -51,7 +56,7 @@ func TestService_Lifecycle(t *testing.T) {
 		"test": "data",
 	}
 
-	output, err := service.ProcessData(testData)
+	output, err := service.ProcessData(context.Background(), testData)
 	if err != nil {
 		t.Fatalf("Failed to process data: %v", err)
 	}
@@ -155,3 +160,186 @@ func TestService_UnknownProcessor(t *testing.T) {
 		t.Errorf("Expected 1 processor, got %d", len(service.processors))
 	}
 }
+
+func TestService_PipelineProcessorChains(t *testing.T) {
+	upsert := func(value string) map[string]interface{} {
+		return map[string]interface{}{
+			"actions": []interface{}{
+				map[string]interface{}{"key": "tier", "value": value, "action": "upsert"},
+			},
+		}
+	}
+	config := harness.CollectorConfig{
+		Receivers: map[string]interface{}{"otlp": map[string]interface{}{}},
+		Processors: map[string]interface{}{
+			"attributes/first":  upsert("first"),
+			"attributes/second": upsert("second"),
+		},
+		Exporters: map[string]interface{}{"logging": map[string]interface{}{}},
+		Service: map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces/checkout": map[string]interface{}{
+					"receivers":  []interface{}{"otlp"},
+					"processors": []interface{}{"attributes/second", "attributes/first"},
+					"exporters":  []interface{}{"logging"},
+				},
+				"traces/payments": map[string]interface{}{
+					"receivers":  []interface{}{"otlp"},
+					"processors": []interface{}{"attributes/first", "attributes/second"},
+					"exporters":  []interface{}{"logging"},
+				},
+				"metrics/checkout": map[string]interface{}{
+					"receivers":  []interface{}{"otlp"},
+					"processors": []interface{}{"attributes/second"},
+					"exporters":  []interface{}{"logging"},
+				},
+			},
+		},
+	}
+
+	service := NewService(config, zap.NewNop())
+	if err := service.Start(); err != nil {
+		t.Fatalf("Failed to start service: %v", err)
+	}
+	defer service.Stop()
+
+	data := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	data.Traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /cart")
+	metric := data.Metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	data.Logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("started")
+
+	service.SetPipeline("traces/checkout")
+	output, err := service.ProcessData(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Failed to process data: %v", err)
+	}
+	result := output.(contract.OpenTelemetryData)
+
+	// The last processor in the checkout pipeline wins
+	span := result.Traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	if tier, _ := span.Attributes().Get("tier"); tier.Str() != "first" {
+		t.Errorf("Expected traces to run attributes/second then attributes/first, got tier %q", tier.Str())
+	}
+
+	// Metrics run through the checkout metrics pipeline only
+	dp := result.Metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	if tier, _ := dp.Attributes().Get("tier"); tier.Str() != "second" {
+		t.Errorf("Expected metrics to run only attributes/second, got tier %q", tier.Str())
+	}
+
+	// Logs have no pipeline and pass through untouched
+	record := result.Logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	if _, exists := record.Attributes().Get("tier"); exists {
+		t.Error("Expected logs without a pipeline to be left unprocessed")
+	}
+}
+
+func TestCollectorConfig_SelectPipeline(t *testing.T) {
+	config := harness.CollectorConfig{
+		Service: map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces":          map[string]interface{}{},
+				"traces/checkout": map[string]interface{}{},
+				"metrics/checkout": map[string]interface{}{
+					"processors": []interface{}{"batch", "attributes/redact"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		signal string
+		name   string
+		want   string
+	}{
+		{harness.SignalTraces, "traces/checkout", "traces/checkout"},
+		{harness.SignalTraces, "checkout", "traces/checkout"},
+		{harness.SignalTraces, "traces", "traces"},
+		{harness.SignalTraces, "", "traces"},
+		{harness.SignalMetrics, "traces/checkout", "metrics/checkout"},
+		{harness.SignalMetrics, "metrics", "metrics/checkout"},
+	}
+	for _, tt := range tests {
+		pipeline, ok, err := config.SelectPipeline(tt.signal, tt.name)
+		if err != nil || !ok {
+			t.Fatalf("SelectPipeline(%s, %s) failed: ok=%v err=%v", tt.signal, tt.name, ok, err)
+		}
+		if pipeline.ID.String() != tt.want {
+			t.Errorf("SelectPipeline(%s, %s): expected %s, got %s", tt.signal, tt.name, tt.want, pipeline.ID)
+		}
+	}
+
+	if _, ok, _ := config.SelectPipeline(harness.SignalLogs, "checkout"); ok {
+		t.Error("Expected no logs pipeline to be selected")
+	}
+	for _, name := range []string{"unknown", "traces/unknown"} {
+		if _, ok, err := config.SelectPipeline(harness.SignalMetrics, name); ok || err == nil {
+			t.Errorf("Expected an error for the unknown pipeline %s, got ok=%v err=%v", name, ok, err)
+		}
+	}
+
+	pipeline, _, _ := config.SelectPipeline(harness.SignalMetrics, "checkout")
+	if got := pipeline.Processors[1]; got.Type != "attributes" || got.Name != "redact" {
+		t.Errorf("Expected attributes/redact to resolve to type attributes, name redact, got %+v", got)
+	}
+	if _, err := config.ProcessorChain(harness.SignalMetrics, "checkout"); err == nil {
+		t.Error("Expected an error for processors missing from the processors section")
+	}
+}
+
+func TestService_EngineChainsProcessorsWithoutMutatingInput(t *testing.T) {
+	config := harness.CollectorConfig{
+		Processors: map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"actions": []interface{}{
+					map[string]interface{}{"key": "user.email", "action": "delete"},
+				},
+			},
+		},
+		Service: map[string]interface{}{
+			"pipelines": map[string]interface{}{
+				"traces": map[string]interface{}{
+					"processors": []interface{}{"attributes"},
+				},
+			},
+		},
+	}
+
+	input := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	span := input.Traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("user.email", "someone@example.com")
+
+	service := NewService(config, zap.NewNop())
+	if err := service.Start(); err != nil {
+		t.Fatalf("Failed to start service: %v", err)
+	}
+	defer service.Stop()
+
+	output, err := service.ProcessData(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Failed to process data: %v", err)
+	}
+	serviceSpan := output.(contract.OpenTelemetryData).Traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	if _, exists := serviceSpan.Attributes().Get("user.email"); exists {
+		t.Error("Expected the attributes processor to delete user.email from the output")
+	}
+	if _, exists := span.Attributes().Get("user.email"); !exists {
+		t.Error("Expected the input to be left unchanged")
+	}
+
+	// The harness builds the same chain from the same processor factory
+	processors, err := harness.BuildProcessors(config, NewProcessorFactory(zap.NewNop()))
+	if err != nil {
+		t.Fatalf("Failed to build processors: %v", err)
+	}
+	simulated, err := harness.NewEngine(config, processors, zap.NewNop()).Process(context.Background(), "", input)
+	if err != nil {
+		t.Fatalf("Failed to process data through the engine: %v", err)
+	}
+	simulatedSpan := simulated.Traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	if !simulatedSpan.Attributes().Equal(serviceSpan.Attributes()) {
+		t.Errorf("Expected simulation to match the collector service, got %v and %v",
+			simulatedSpan.Attributes().AsRaw(), serviceSpan.Attributes().AsRaw())
+	}
+}
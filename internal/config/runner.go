@@ -7,9 +7,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/goedelsoup/waveform/internal/contract"
+	"github.com/goedelsoup/waveform/internal/harness"
 	"gopkg.in/yaml.v3"
 )
 
@@ -35,7 +39,7 @@ type RunnerSettings struct {
 	LogFormat string `yaml:"log_format" toml:"log_format" default:"json"`
 
 	// Test execution settings
-	Timeout  Duration `yaml:"timeout" toml:"timeout" default:"30s"`
+	Timeout  Duration `yaml:"timeout" toml:"timeout"` // Unset for no contract timeout
 	Parallel int      `yaml:"parallel" toml:"parallel" default:"1"`
 
 	// Output settings
@@ -134,8 +138,8 @@ type GlobalSettings struct {
 	// Default environment
 	Environment string `yaml:"environment" toml:"environment" default:"development"`
 
-	// Default timeout for operations
-	DefaultTimeout Duration `yaml:"default_timeout" toml:"default_timeout" default:"30s"`
+	// Default contract timeout, unset for none
+	DefaultTimeout Duration `yaml:"default_timeout" toml:"default_timeout"`
 
 	// Whether to fail fast on errors
 	FailFast bool `yaml:"fail_fast" toml:"fail_fast" default:"false"`
@@ -146,8 +150,8 @@ type GlobalSettings struct {
 
 // RetrySettings configures retry behavior
 type RetrySettings struct {
-	// Maximum number of retries
-	MaxAttempts int `yaml:"max_attempts" toml:"max_attempts" default:"3"`
+	// Maximum number of attempts per contract, including the first
+	MaxAttempts int `yaml:"max_attempts" toml:"max_attempts" default:"1"`
 
 	// Initial backoff duration
 	InitialBackoff Duration `yaml:"initial_backoff" toml:"initial_backoff" default:"1s"`
@@ -162,6 +166,144 @@ type RetrySettings struct {
 // Duration represents a duration string that can be parsed
 type Duration string
 
+// Parse returns the duration, or 0 when it is unset
+func (d Duration) Parse() (time.Duration, error) {
+	if d == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(string(d))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", string(d), err)
+	}
+	return duration, nil
+}
+
+// ContractTimeout returns how long each contract may run: runner.timeout, or
+// global.default_timeout when that is unset. 0, the default, means no timeout.
+func (c *RunnerConfig) ContractTimeout() (time.Duration, error) {
+	timeout, err := c.Runner.Timeout.Parse()
+	if err != nil {
+		return 0, fmt.Errorf("runner.timeout: %w", err)
+	}
+	if timeout > 0 {
+		return timeout, nil
+	}
+	timeout, err = c.Global.DefaultTimeout.Parse()
+	if err != nil {
+		return 0, fmt.Errorf("global.default_timeout: %w", err)
+	}
+	return timeout, nil
+}
+
+// Policy returns the retry policy for failed contracts
+func (r RetrySettings) Policy() (harness.RetryPolicy, error) {
+	if r.MaxAttempts < 0 {
+		return harness.RetryPolicy{}, fmt.Errorf("max_attempts %d must not be negative", r.MaxAttempts)
+	}
+	if r.BackoffMultiplier != 0 && r.BackoffMultiplier < 1 {
+		return harness.RetryPolicy{}, fmt.Errorf("backoff_multiplier %g must be at least 1", r.BackoffMultiplier)
+	}
+	initial, err := r.InitialBackoff.Parse()
+	if err != nil {
+		return harness.RetryPolicy{}, fmt.Errorf("initial_backoff: %w", err)
+	}
+	maxBackoff, err := r.MaxBackoff.Parse()
+	if err != nil {
+		return harness.RetryPolicy{}, fmt.Errorf("max_backoff: %w", err)
+	}
+	return harness.RetryPolicy{
+		MaxAttempts:       r.MaxAttempts,
+		InitialBackoff:    initial,
+		MaxBackoff:        maxBackoff,
+		BackoffMultiplier: r.BackoffMultiplier,
+	}, nil
+}
+
+// PipelineEnricher returns an enricher that adds the runner configuration of each
+// collector pipeline to its selector info. A collector applies when its config_path is
+// empty or names the collector configuration in use, and its pipelines are keyed by
+// pipeline ID. Environment settings become tags, with global.environment as the default
+// environment tag. The pipeline's priority is the sum of the priorities of the global
+// pipeline_selectors and its own selectors that it satisfies, and its specificity the sum
+// of how precisely it satisfies them.
+func (c *RunnerConfig) PipelineEnricher(collectorConfigPath string) harness.PipelineEnricher {
+	names := make([]string, 0, len(c.Collectors))
+	for name, collector := range c.Collectors {
+		if collector.ConfigPath == "" || samePath(collector.ConfigPath, collectorConfigPath) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return func(info *contract.PipelineInfo) {
+		if c.Global.Environment != "" {
+			info.Tags["environment"] = c.Global.Environment
+		}
+		selectors := c.PipelineSelectors
+		for _, name := range names {
+			collector := c.Collectors[name]
+			pipeline, ok := collector.Pipelines[info.ID]
+			if !ok {
+				continue
+			}
+			selectors = append(selectors[:len(selectors):len(selectors)], pipeline.Selectors...)
+
+			for key, value := range collector.Environment {
+				info.Tags[key] = fmt.Sprintf("%v", value)
+			}
+			for key, value := range pipeline.Environment {
+				info.Tags[key] = fmt.Sprintf("%v", value)
+			}
+			if pipeline.Name != "" {
+				info.Name = pipeline.Name
+			}
+			if pipeline.Description != "" {
+				info.Description = pipeline.Description
+			}
+			info.Metadata["collector"] = name
+			if collector.Name != "" {
+				info.Metadata["collector_name"] = collector.Name
+			}
+			if len(collector.Tags) > 0 {
+				info.Metadata["collector_tags"] = strings.Join(collector.Tags, ",")
+			}
+			if len(pipeline.Signals) > 0 {
+				info.Metadata["signals"] = strings.Join(pipeline.Signals, ",")
+			}
+		}
+
+		// Priorities are applied once tags and metadata are complete
+		for _, selector := range selectors {
+			if contract.MatchesPipeline(info, selector.toContract()) {
+				info.Priority += selector.Priority
+				info.Specificity += contract.SelectorSpecificity(info, selector.toContract())
+			}
+		}
+	}
+}
+
+// toContract converts the selector to the form contracts use
+func (s PipelineSelector) toContract() contract.PipelineSelector {
+	return contract.PipelineSelector{
+		Field:    s.Field,
+		Operator: contract.PipelineSelectorOperator(s.Operator),
+		Value:    s.Value,
+	}
+}
+
+// samePath reports whether two file paths name the same file
+func samePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return absA == absB
+}
+
 // RunnerConfigLoader handles loading runner configuration files
 type RunnerConfigLoader struct{}
 
@@ -274,7 +416,6 @@ func (l *RunnerConfigLoader) getDefaultConfig() *RunnerConfig {
 		Runner: RunnerSettings{
 			LogLevel:  "info",
 			LogFormat: "json",
-			Timeout:   "30s",
 			Parallel:  1,
 			Output: OutputSettings{
 				Formats:   []string{"summary"},
@@ -292,11 +433,10 @@ func (l *RunnerConfigLoader) getDefaultConfig() *RunnerConfig {
 		Collectors:        make(map[string]CollectorDefinition),
 		PipelineSelectors: []PipelineSelector{},
 		Global: GlobalSettings{
-			Environment:    "development",
-			DefaultTimeout: "30s",
-			FailFast:       false,
+			Environment: "development",
+			FailFast:    false,
 			Retry: RetrySettings{
-				MaxAttempts:       3,
+				MaxAttempts:       1,
 				InitialBackoff:    "1s",
 				MaxBackoff:        "30s",
 				BackoffMultiplier: 2.0,
@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,12 +25,16 @@ func TestRunnerConfigLoader_LoadConfig_Default(t *testing.T) {
 	// Verify default values
 	assert.Equal(t, "info", config.Runner.LogLevel)
 	assert.Equal(t, "json", config.Runner.LogFormat)
-	assert.Equal(t, "30s", string(config.Runner.Timeout))
+	// Contracts have no timeout unless one is configured
+	assert.Empty(t, config.Runner.Timeout)
+	assert.Empty(t, config.Global.DefaultTimeout)
 	assert.Equal(t, 1, config.Runner.Parallel)
 	assert.Equal(t, "./waveform-reports", config.Runner.Output.Directory)
 	assert.Equal(t, []string{"summary"}, config.Runner.Output.Formats)
 	assert.True(t, config.Runner.Cache.Enabled)
 	assert.Equal(t, "development", config.Global.Environment)
+	// Retries are opt-in
+	assert.Equal(t, 1, config.Global.Retry.MaxAttempts)
 }
 
 func TestRunnerConfigLoader_LoadFromFile_YAML(t *testing.T) {
@@ -562,3 +568,111 @@ func TestRunnerConfigLoader_SaveConfig_TOML(t *testing.T) {
 	assert.Equal(t, "Test collector", collector.Description)
 	assert.Equal(t, "/tmp/test.yaml", collector.ConfigPath)
 }
+
+func TestRunnerConfig_ContractTimeout(t *testing.T) {
+	config := &RunnerConfig{
+		Runner: RunnerSettings{Timeout: "5s"},
+		Global: GlobalSettings{DefaultTimeout: "30s"},
+	}
+	timeout, err := config.ContractTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+
+	// global.default_timeout applies when runner.timeout is unset
+	config.Runner.Timeout = ""
+	timeout, err = config.ContractTimeout()
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, timeout)
+
+	config.Global.DefaultTimeout = ""
+	timeout, err = config.ContractTimeout()
+	require.NoError(t, err)
+	assert.Zero(t, timeout)
+
+	config.Runner.Timeout = "soon"
+	_, err = config.ContractTimeout()
+	assert.ErrorContains(t, err, `runner.timeout: invalid duration "soon"`)
+}
+
+func TestRetrySettings_Policy(t *testing.T) {
+	retry := RetrySettings{MaxAttempts: 3, InitialBackoff: "1s", MaxBackoff: "30s", BackoffMultiplier: 2.0}
+	policy, err := retry.Policy()
+	require.NoError(t, err)
+	assert.Equal(t, 3, policy.MaxAttempts)
+	assert.Equal(t, time.Second, policy.InitialBackoff)
+	assert.Equal(t, 30*time.Second, policy.MaxBackoff)
+	assert.Equal(t, 2.0, policy.BackoffMultiplier)
+
+	retry.InitialBackoff = "soon"
+	_, err = retry.Policy()
+	assert.ErrorContains(t, err, `initial_backoff: invalid duration "soon"`)
+
+	retry.InitialBackoff = "1s"
+	retry.BackoffMultiplier = 0.5
+	_, err = retry.Policy()
+	assert.ErrorContains(t, err, "backoff_multiplier 0.5 must be at least 1")
+}
+
+func TestRunnerConfig_PipelineEnricher(t *testing.T) {
+	config := &RunnerConfig{
+		Collectors: map[string]CollectorDefinition{
+			"production": {
+				Name:        "production-collector",
+				ConfigPath:  "collector.yaml",
+				Tags:        []string{"production", "main"},
+				Environment: map[string]interface{}{"datacenter": "us-west-1", "tier": "production"},
+				Pipelines: map[string]PipelineConfig{
+					"traces/auth": {
+						Name:        "auth-traces",
+						Description: "Auth service traces",
+						Signals:     []string{"traces"},
+						Environment: map[string]interface{}{"tier": "critical", "sampling_rate": 0.1},
+						Selectors: []PipelineSelector{
+							{Field: "type", Operator: "equals", Value: "trace", Priority: 3},
+							{Field: "tags.tier", Operator: "equals", Value: "production", Priority: 100},
+						},
+					},
+				},
+			},
+			"staging": {
+				ConfigPath: "staging.yaml",
+				Pipelines:  map[string]PipelineConfig{"traces/auth": {Name: "staging-traces"}},
+			},
+		},
+		PipelineSelectors: []PipelineSelector{
+			{Field: "tags.environment", Operator: "equals", Value: "production", Priority: 5},
+			{Field: "type", Operator: "equals", Value: "metric", Priority: 50},
+		},
+		Global: GlobalSettings{Environment: "production"},
+	}
+	enrich := config.PipelineEnricher("./collector.yaml")
+
+	info := &contract.PipelineInfo{ID: "traces/auth", Name: "traces/auth", Type: "trace", Tags: map[string]string{}, Metadata: map[string]string{}}
+	enrich(info)
+	assert.Equal(t, "auth-traces", info.Name)
+	assert.Equal(t, "Auth service traces", info.Description)
+	assert.Equal(t, map[string]string{
+		"environment":   "production",
+		"datacenter":    "us-west-1",
+		"tier":          "critical",
+		"sampling_rate": "0.1",
+	}, info.Tags)
+	assert.Equal(t, map[string]string{
+		"collector":      "production",
+		"collector_name": "production-collector",
+		"collector_tags": "production,main",
+		"signals":        "traces",
+	}, info.Metadata)
+	// The global environment selector and the pipeline's own type selector apply
+	assert.Equal(t, 8, info.Priority)
+	assert.Equal(t, 8, info.Specificity)
+
+	// Pipelines without runner configuration only get the global environment
+	other := &contract.PipelineInfo{ID: "logs", Name: "logs", Type: "log", Tags: map[string]string{}, Metadata: map[string]string{}}
+	enrich(other)
+	assert.Equal(t, "logs", other.Name)
+	assert.Equal(t, map[string]string{"environment": "production"}, other.Tags)
+	assert.Empty(t, other.Metadata)
+	assert.Equal(t, 5, other.Priority)
+	assert.Equal(t, 4, other.Specificity)
+}
@@ -0,0 +1,247 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// CompareOptions controls how expected contract values are compared with telemetry values
+type CompareOptions struct {
+	// StrictTypes requires the expected and actual types to agree, so "1" does not equal 1
+	StrictTypes bool `yaml:"strict_types,omitempty"`
+	// Tolerance is the absolute difference allowed between numeric values
+	Tolerance float64 `yaml:"tolerance,omitempty"`
+}
+
+// CompareValue compares an expected contract value with an actual attribute value.
+// It returns false and a message naming both types and values when they differ.
+func CompareValue(expected interface{}, actual pcommon.Value, opts CompareOptions) (bool, string) {
+	return compareValueAt("", expected, actual, opts)
+}
+
+// compareValueAt compares values recursively, prefixing messages with the nested path
+func compareValueAt(path string, expected interface{}, actual pcommon.Value, opts CompareOptions) (bool, string) {
+	if valueEquals(expected, actual, opts) {
+		return true, ""
+	}
+
+	switch actual.Type() {
+	case pcommon.ValueTypeSlice:
+		if items, ok := expected.([]interface{}); ok {
+			if len(items) != actual.Slice().Len() {
+				return false, fmt.Sprintf("%sexpected slice of length %d, got slice of length %d",
+					pathPrefix(path), len(items), actual.Slice().Len())
+			}
+			for i, item := range items {
+				if ok, msg := compareValueAt(fmt.Sprintf("%s[%d]", path, i), item, actual.Slice().At(i), opts); !ok {
+					return false, msg
+				}
+			}
+		}
+	case pcommon.ValueTypeMap:
+		if fields, ok := toStringMap(expected); ok {
+			for _, key := range sortedMapKeys(fields) {
+				value, exists := actual.Map().Get(key)
+				if !exists {
+					return false, fmt.Sprintf("%smissing key %q", pathPrefix(path), key)
+				}
+				if ok, msg := compareValueAt(path+"."+key, fields[key], value, opts); !ok {
+					return false, msg
+				}
+			}
+			var extra string
+			actual.Map().Range(func(key string, _ pcommon.Value) bool {
+				if _, ok := fields[key]; !ok {
+					extra = key
+					return false
+				}
+				return true
+			})
+			if extra != "" {
+				return false, fmt.Sprintf("%sunexpected key %q", pathPrefix(path), extra)
+			}
+		}
+	}
+
+	return false, fmt.Sprintf("%sexpected %s, got %s", pathPrefix(path), DescribeExpected(expected), DescribeValue(actual))
+}
+
+// valueEquals reports whether an expected value equals an actual attribute value
+func valueEquals(expected interface{}, actual pcommon.Value, opts CompareOptions) bool {
+	switch actual.Type() {
+	case pcommon.ValueTypeEmpty:
+		return expected == nil
+	case pcommon.ValueTypeStr:
+		if s, ok := expected.(string); ok {
+			return s == actual.Str()
+		}
+		if opts.StrictTypes || expected == nil {
+			return false
+		}
+		return stringify(expected) == actual.Str() || numbersEqual(expected, actual.Str(), opts.Tolerance)
+	case pcommon.ValueTypeInt:
+		if isInteger(expected) || !opts.StrictTypes {
+			return numbersEqual(expected, actual.Int(), opts.Tolerance)
+		}
+		return false
+	case pcommon.ValueTypeDouble:
+		switch expected.(type) {
+		case float32, float64:
+			return numbersEqual(expected, actual.Double(), opts.Tolerance)
+		}
+		if opts.StrictTypes {
+			return false
+		}
+		return numbersEqual(expected, actual.Double(), opts.Tolerance)
+	case pcommon.ValueTypeBool:
+		if b, ok := expected.(bool); ok {
+			return b == actual.Bool()
+		}
+		if s, ok := expected.(string); ok && !opts.StrictTypes {
+			parsed, err := strconv.ParseBool(s)
+			return err == nil && parsed == actual.Bool()
+		}
+		return false
+	case pcommon.ValueTypeBytes:
+		// YAML has no byte type, so strings match either the raw bytes or their base64 encoding
+		raw := actual.Bytes().AsRaw()
+		switch v := expected.(type) {
+		case []byte:
+			return bytes.Equal(v, raw)
+		case string:
+			return v == string(raw) || v == base64.StdEncoding.EncodeToString(raw)
+		}
+		return false
+	case pcommon.ValueTypeSlice:
+		items, ok := expected.([]interface{})
+		if !ok || len(items) != actual.Slice().Len() {
+			return false
+		}
+		for i, item := range items {
+			if !valueEquals(item, actual.Slice().At(i), opts) {
+				return false
+			}
+		}
+		return true
+	case pcommon.ValueTypeMap:
+		fields, ok := toStringMap(expected)
+		if !ok || len(fields) != actual.Map().Len() {
+			return false
+		}
+		for key, field := range fields {
+			value, exists := actual.Map().Get(key)
+			if !exists || !valueEquals(field, value, opts) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// numbersEqual compares two values numerically within an absolute tolerance
+func numbersEqual(a, b interface{}, tolerance float64) bool {
+	af, err := ToNumeric(a)
+	if err != nil {
+		return false
+	}
+	bf, err := ToNumeric(b)
+	if err != nil {
+		return false
+	}
+	if tolerance > 0 {
+		return math.Abs(af-bf) <= tolerance
+	}
+	return af == bf
+}
+
+// isInteger reports whether a value has an integer Go type
+func isInteger(value interface{}) bool {
+	switch value.(type) {
+	case int, int32, int64, uint64:
+		return true
+	}
+	return false
+}
+
+// toStringMap converts the map forms produced by YAML decoding into map[string]interface{}
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[fmt.Sprintf("%v", key)] = item
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// sortedMapKeys returns the keys of a map in a stable order
+func sortedMapKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pathPrefix formats a nested value path for messages
+func pathPrefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return "at " + path + ": "
+}
+
+// DescribeExpected formats an expected contract value with its type, e.g. int 1 or str "1"
+func DescribeExpected(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "empty"
+	case string:
+		return fmt.Sprintf("str %q", v)
+	case bool:
+		return fmt.Sprintf("bool %t", v)
+	case int, int32, int64, uint64:
+		return fmt.Sprintf("int %v", v)
+	case float32, float64:
+		return fmt.Sprintf("double %v", v)
+	case []byte:
+		return fmt.Sprintf("bytes %s", base64.StdEncoding.EncodeToString(v))
+	case []interface{}:
+		return fmt.Sprintf("slice %v", v)
+	default:
+		if fields, ok := toStringMap(value); ok {
+			return fmt.Sprintf("map %v", fields)
+		}
+		return fmt.Sprintf("%T %v", value, value)
+	}
+}
+
+// DescribeValue formats an attribute value with its type, e.g. int 1 or str "1"
+func DescribeValue(value pcommon.Value) string {
+	switch value.Type() {
+	case pcommon.ValueTypeEmpty:
+		return "empty"
+	case pcommon.ValueTypeStr:
+		return fmt.Sprintf("str %q", value.Str())
+	case pcommon.ValueTypeBytes:
+		return fmt.Sprintf("bytes %s", base64.StdEncoding.EncodeToString(value.Bytes().AsRaw()))
+	default:
+		return fmt.Sprintf("%s %s", strings.ToLower(value.Type().String()), value.AsString())
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestCompareValue(t *testing.T) {
+	slice := pcommon.NewValueSlice()
+	slice.Slice().AppendEmpty().SetStr("a")
+	slice.Slice().AppendEmpty().SetInt(2)
+
+	nested := pcommon.NewValueMap()
+	nested.Map().PutStr("id", "42")
+	nested.Map().PutEmptySlice("roles").AppendEmpty().SetStr("admin")
+
+	raw := pcommon.NewValueBytes()
+	raw.Bytes().FromRaw([]byte("hello"))
+
+	tests := []struct {
+		name     string
+		expected interface{}
+		actual   pcommon.Value
+		options  CompareOptions
+		want     bool
+	}{
+		{"string", "GET", pcommon.NewValueStr("GET"), CompareOptions{}, true},
+		{"int", 200, pcommon.NewValueInt(200), CompareOptions{}, true},
+		{"int against double", 2, pcommon.NewValueDouble(2.0), CompareOptions{}, true},
+		{"double within tolerance", 0.5, pcommon.NewValueDouble(0.5004), CompareOptions{Tolerance: 0.001}, true},
+		{"double outside tolerance", 0.5, pcommon.NewValueDouble(0.51), CompareOptions{Tolerance: 0.001}, false},
+		{"string against int", "1", pcommon.NewValueInt(1), CompareOptions{}, true},
+		{"strict string against int", "1", pcommon.NewValueInt(1), CompareOptions{StrictTypes: true}, false},
+		{"strict int against string", 1, pcommon.NewValueStr("1"), CompareOptions{StrictTypes: true}, false},
+		{"strict int against double", 2, pcommon.NewValueDouble(2.0), CompareOptions{StrictTypes: true}, false},
+		{"bool", true, pcommon.NewValueBool(true), CompareOptions{}, true},
+		{"bool string", "false", pcommon.NewValueBool(true), CompareOptions{}, false},
+		{"bytes raw", "hello", raw, CompareOptions{}, true},
+		{"bytes base64", "aGVsbG8=", raw, CompareOptions{}, true},
+		{"slice", []interface{}{"a", 2}, slice, CompareOptions{}, true},
+		{"slice order", []interface{}{2, "a"}, slice, CompareOptions{}, false},
+		{"map", map[string]interface{}{"id": "42", "roles": []interface{}{"admin"}}, nested, CompareOptions{}, true},
+		{"map missing key", map[string]interface{}{"id": "42"}, nested, CompareOptions{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, msg := CompareValue(tt.expected, tt.actual, tt.options)
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v (%s)", tt.want, got, msg)
+			}
+		})
+	}
+}
+
+func TestCompareValue_Messages(t *testing.T) {
+	_, msg := CompareValue("1", pcommon.NewValueInt(1), CompareOptions{StrictTypes: true})
+	if msg != `expected str "1", got int 1` {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+
+	nested := pcommon.NewValueMap()
+	nested.Map().PutEmptySlice("roles").AppendEmpty().SetStr("admin")
+	_, msg = CompareValue(map[string]interface{}{"roles": []interface{}{"viewer"}}, nested, CompareOptions{})
+	if !strings.Contains(msg, `at .roles[0]: expected str "viewer", got str "admin"`) {
+		t.Errorf("Unexpected message: %s", msg)
+	}
+}
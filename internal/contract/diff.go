@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffKind marks how a field differs between the expected shape and the actual item
+type DiffKind string
+
+const (
+	DiffAdded     DiffKind = "added"     // Present on the actual item but expected to be absent
+	DiffRemoved   DiffKind = "removed"   // Expected but missing from the actual item
+	DiffChanged   DiffKind = "changed"   // Present on the actual item with a different value
+	DiffUnchanged DiffKind = "unchanged" // Context from the actual item that satisfied or was not constrained by the matcher
+)
+
+// DiffSection groups diff entries by where the field lives
+type DiffSection string
+
+const (
+	DiffSectionResource  DiffSection = "resource"
+	DiffSectionScope     DiffSection = "scope"
+	DiffSectionItem      DiffSection = "item"
+	DiffSectionAttribute DiffSection = "attribute"
+)
+
+// diffSections is the order sections are rendered in
+var diffSections = []DiffSection{DiffSectionResource, DiffSectionScope, DiffSectionItem, DiffSectionAttribute}
+
+// diffMarkers prefixes each entry when rendered
+var diffMarkers = map[DiffKind]string{
+	DiffAdded:     "+",
+	DiffRemoved:   "-",
+	DiffChanged:   "~",
+	DiffUnchanged: " ",
+}
+
+// DiffEntry is a single field of the diff
+type DiffEntry struct {
+	Section  DiffSection
+	Field    string
+	Kind     DiffKind
+	Expected string
+	Actual   string
+	Message  string
+}
+
+// TelemetryDiff compares the expected shape of a matcher with the closest actual item
+type TelemetryDiff struct {
+	SignalType SignalType
+	Matcher    string // The matcher the diff belongs to, such as "trace matcher 0"
+	Location   string // Where the compared item was found in the output
+	Entries    []DiffEntry
+}
+
+// HasDifferences reports whether any entry is not unchanged context
+func (d *TelemetryDiff) HasDifferences() bool {
+	for _, entry := range d.Entries {
+		if entry.Kind != DiffUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// Render formats the diff as indented lines grouped by section, each entry prefixed
+// with + (added), - (removed), ~ (changed) or a space for context
+func (d *TelemetryDiff) Render(indent string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s vs %s:\n", indent, d.Matcher, d.Location)
+	for _, section := range diffSections {
+		var lines []string
+		for _, entry := range d.Entries {
+			if entry.Section == section {
+				lines = append(lines, fmt.Sprintf("%s    %s %s", indent, diffMarkers[entry.Kind], entry.describe()))
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s  %s:\n", indent, section)
+		for _, line := range lines {
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// describe formats the entry's field and values
+func (e DiffEntry) describe() string {
+	switch {
+	case e.Kind == DiffUnchanged || e.Kind == DiffAdded:
+		if e.Actual != "" {
+			return fmt.Sprintf("%s: %s", e.Field, e.Actual)
+		}
+	case e.Kind == DiffRemoved && e.Expected != "":
+		return fmt.Sprintf("%s: expected %s", e.Field, e.Expected)
+	case e.Expected != "" && e.Actual != "":
+		return fmt.Sprintf("%s: expected %s, got %s", e.Field, e.Expected, e.Actual)
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("%s: %s", e.Field, e.Message)
+	}
+	return e.Field
+}
+
+// DiffSectionOf classifies a matcher field path into the section it is rendered under
+func DiffSectionOf(field string) DiffSection {
+	switch {
+	case strings.HasPrefix(field, "resource."):
+		return DiffSectionResource
+	case strings.HasPrefix(field, "scope."):
+		return DiffSectionScope
+	case strings.HasPrefix(field, "attributes.") || strings.HasPrefix(field, "labels."):
+		return DiffSectionAttribute
+	default:
+		return DiffSectionItem
+	}
+}
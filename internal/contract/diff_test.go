@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import "testing"
+
+func TestTelemetryDiff_Render(t *testing.T) {
+	diff := &TelemetryDiff{
+		Matcher:  "trace matcher 0",
+		Location: "resource 0, scope 0, span 0",
+		Entries: []DiffEntry{
+			{Section: DiffSectionAttribute, Field: "attributes.debug", Kind: DiffAdded, Actual: `"1"`},
+			{Section: DiffSectionAttribute, Field: "attributes.http.method", Kind: DiffChanged, Expected: `"POST"`, Actual: `"GET"`},
+			{Section: DiffSectionResource, Field: "resource.attributes.service.name", Kind: DiffRemoved, Expected: `"cart"`},
+			{Section: DiffSectionItem, Field: "duration", Kind: DiffChanged, Message: "duration 2s exceeds max 1s"},
+			{Section: DiffSectionItem, Field: "span_name", Kind: DiffUnchanged, Actual: `"GET /cart"`},
+		},
+	}
+
+	expected := "trace matcher 0 vs resource 0, scope 0, span 0:\n" +
+		"  resource:\n" +
+		"    - resource.attributes.service.name: expected \"cart\"\n" +
+		"  item:\n" +
+		"    ~ duration: duration 2s exceeds max 1s\n" +
+		"      span_name: \"GET /cart\"\n" +
+		"  attribute:\n" +
+		"    + attributes.debug: \"1\"\n" +
+		"    ~ attributes.http.method: expected \"POST\", got \"GET\"\n"
+	if got := diff.Render(""); got != expected {
+		t.Errorf("Unexpected rendering:\n%s\nexpected:\n%s", got, expected)
+	}
+	if !diff.HasDifferences() {
+		t.Error("Expected diff to have differences")
+	}
+}
+
+func TestDiffSectionOf(t *testing.T) {
+	tests := map[string]DiffSection{
+		"resource.attributes.service.name": DiffSectionResource,
+		"resource.schema_url":              DiffSectionResource,
+		"scope.name":                       DiffSectionScope,
+		"attributes.http.route":            DiffSectionAttribute,
+		"labels.method":                    DiffSectionAttribute,
+		"span_name":                        DiffSectionItem,
+		"events[0].name":                   DiffSectionItem,
+	}
+	for field, expected := range tests {
+		if got := DiffSectionOf(field); got != expected {
+			t.Errorf("DiffSectionOf(%q) = %s, expected %s", field, got, expected)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// expressionTypeKey is the inline expression key that asserts an attribute's value type
+const expressionTypeKey = "type"
+
+// literalKey wraps an expected value that must be compared literally, for map values
+// whose keys would otherwise read as an expression, such as {literal: {type: x}}
+const literalKey = "literal"
+
+// expressionOperators maps inline expression keys to filter operators. Every filter
+// operator is accepted by name, alongside short forms for comparisons.
+var expressionOperators = map[string]FilterOperator{
+	"eq":                                 FilterOperatorEquals,
+	"ne":                                 FilterOperatorNotEquals,
+	"gt":                                 FilterOperatorGreaterThan,
+	"gte":                                FilterOperatorGreaterOrEqual,
+	"lt":                                 FilterOperatorLessThan,
+	"lte":                                FilterOperatorLessOrEqual,
+	string(FilterOperatorEquals):         FilterOperatorEquals,
+	string(FilterOperatorNotEquals):      FilterOperatorNotEquals,
+	string(FilterOperatorMatches):        FilterOperatorMatches,
+	string(FilterOperatorNotMatches):     FilterOperatorNotMatches,
+	string(FilterOperatorExists):         FilterOperatorExists,
+	string(FilterOperatorNotExists):      FilterOperatorNotExists,
+	string(FilterOperatorGreaterThan):    FilterOperatorGreaterThan,
+	string(FilterOperatorLessThan):       FilterOperatorLessThan,
+	string(FilterOperatorGreaterOrEqual): FilterOperatorGreaterOrEqual,
+	string(FilterOperatorLessOrEqual):    FilterOperatorLessOrEqual,
+	string(FilterOperatorContains):       FilterOperatorContains,
+	string(FilterOperatorNotContains):    FilterOperatorNotContains,
+	string(FilterOperatorStartsWith):     FilterOperatorStartsWith,
+	string(FilterOperatorEndsWith):       FilterOperatorEndsWith,
+	string(FilterOperatorInRange):        FilterOperatorInRange,
+	string(FilterOperatorNotInRange):     FilterOperatorNotInRange,
+	string(FilterOperatorOneOf):          FilterOperatorOneOf,
+	string(FilterOperatorNotOneOf):       FilterOperatorNotOneOf,
+}
+
+// valueTypeNames maps the names accepted by {type: ...} to attribute value types
+var valueTypeNames = map[string]pcommon.ValueType{
+	"empty":  pcommon.ValueTypeEmpty,
+	"str":    pcommon.ValueTypeStr,
+	"string": pcommon.ValueTypeStr,
+	"int":    pcommon.ValueTypeInt,
+	"double": pcommon.ValueTypeDouble,
+	"float":  pcommon.ValueTypeDouble,
+	"bool":   pcommon.ValueTypeBool,
+	"bytes":  pcommon.ValueTypeBytes,
+	"slice":  pcommon.ValueTypeSlice,
+	"array":  pcommon.ValueTypeSlice,
+	"map":    pcommon.ValueTypeMap,
+}
+
+// ExpressionCondition is a single operator and operand within an inline expression
+type ExpressionCondition struct {
+	Key      string
+	Operator FilterOperator
+	Operand  interface{}
+}
+
+// Expression is an inline operator expression used as an expected attribute value,
+// such as {matches: "^/api"}, {gt: 0}, {exists: true} or {type: int}
+type Expression struct {
+	Type       string
+	Conditions []ExpressionCondition
+}
+
+// ParseLiteral returns the value wrapped by a {literal: value} mapping
+func ParseLiteral(value interface{}) (interface{}, bool) {
+	fields, ok := toStringMap(value)
+	if !ok || len(fields) != 1 {
+		return nil, false
+	}
+	literal, ok := fields[literalKey]
+	return literal, ok
+}
+
+// ParseExpression returns the inline expression held by an expected attribute value.
+// A mapping is an expression only when every key is an operator or type; any other
+// value, and any value wrapped in {literal: ...}, is compared literally.
+func ParseExpression(value interface{}) (Expression, bool) {
+	if _, ok := ParseLiteral(value); ok {
+		return Expression{}, false
+	}
+	fields, ok := toStringMap(value)
+	if !ok || len(fields) == 0 {
+		return Expression{}, false
+	}
+
+	var expression Expression
+	for _, key := range sortedMapKeys(fields) {
+		if key == expressionTypeKey {
+			typeName, ok := fields[key].(string)
+			if !ok {
+				return Expression{}, false
+			}
+			expression.Type = typeName
+			continue
+		}
+		operator, ok := expressionOperators[key]
+		if !ok {
+			return Expression{}, false
+		}
+		expression.Conditions = append(expression.Conditions, ExpressionCondition{
+			Key:      key,
+			Operator: operator,
+			Operand:  fields[key],
+		})
+	}
+	return expression, true
+}
+
+// Validate checks that every operand has the shape its operator expects
+func (e Expression) Validate() error {
+	if e.Type != "" {
+		if _, ok := valueTypeNames[e.Type]; !ok {
+			return fmt.Errorf("unknown value type %q", e.Type)
+		}
+	}
+	for _, condition := range e.Conditions {
+		switch condition.Operator {
+		case FilterOperatorExists, FilterOperatorNotExists:
+			if _, ok := condition.Operand.(bool); !ok {
+				return fmt.Errorf("%s requires true or false", condition.Key)
+			}
+		default:
+			if condition.Operand == nil {
+				return fmt.Errorf("%s requires a value", condition.Key)
+			}
+			filter := Filter{Operator: condition.Operator, Value: condition.Operand}
+			if err := validateFilterOperand(filter); err != nil {
+				return fmt.Errorf("%s: %w", condition.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RequiresPresence reports whether the attribute must exist for the expression to hold
+func (e Expression) RequiresPresence() bool {
+	if e.Type != "" {
+		return true
+	}
+	for _, condition := range e.Conditions {
+		switch condition.Operator {
+		case FilterOperatorExists:
+			if condition.Operand == true {
+				return true
+			}
+		case FilterOperatorNotExists:
+			if condition.Operand == false {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate checks an attribute against the expression. found reports whether the
+// attribute is present; presence is required unless the expression only asserts absence.
+func (e Expression) Evaluate(actual pcommon.Value, found bool, opts CompareOptions) (bool, string) {
+	if !found {
+		if e.RequiresPresence() {
+			return false, "expected to exist"
+		}
+		return true, ""
+	}
+	for _, condition := range e.Conditions {
+		if (condition.Operator == FilterOperatorExists && condition.Operand == false) ||
+			(condition.Operator == FilterOperatorNotExists && condition.Operand == true) {
+			return false, fmt.Sprintf("expected not to exist, got %s", DescribeValue(actual))
+		}
+	}
+
+	if e.Type != "" && actual.Type() != valueTypeNames[e.Type] {
+		return false, fmt.Sprintf("expected type %s, got %s", e.Type, DescribeValue(actual))
+	}
+
+	for _, condition := range e.Conditions {
+		var ok bool
+		switch condition.Operator {
+		case FilterOperatorExists, FilterOperatorNotExists:
+			continue
+		case FilterOperatorEquals:
+			ok, _ = CompareValue(condition.Operand, actual, opts)
+		case FilterOperatorNotEquals:
+			ok, _ = CompareValue(condition.Operand, actual, opts)
+			ok = !ok
+		default:
+			var err error
+			ok, err = EvaluateOperator(condition.Operator, ValueToInterface(actual), condition.Operand)
+			if err != nil {
+				return false, fmt.Sprintf("%s: %v", condition.Key, err)
+			}
+		}
+		if !ok {
+			return false, fmt.Sprintf("expected %s %s, got %s", condition.Key, describeOperand(condition.Operand), DescribeValue(actual))
+		}
+	}
+	return true, ""
+}
+
+// describeOperand formats an expression operand for messages
+func describeOperand(operand interface{}) string {
+	if s, ok := operand.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", operand)
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseExpression(t *testing.T) {
+	expression, ok := ParseExpression(map[string]interface{}{"gt": 0, "type": "int"})
+	if !ok {
+		t.Fatalf("Expected an expression")
+	}
+	if expression.Type != "int" || len(expression.Conditions) != 1 ||
+		expression.Conditions[0].Operator != FilterOperatorGreaterThan {
+		t.Errorf("Unexpected expression: %+v", expression)
+	}
+
+	// Mappings with other keys are literal map values
+	if _, ok := ParseExpression(map[string]interface{}{"gt": 0, "user": "alice"}); ok {
+		t.Errorf("Expected a literal map, got an expression")
+	}
+	if _, ok := ParseExpression("GET"); ok {
+		t.Errorf("Expected a literal value, got an expression")
+	}
+
+	// {literal: ...} escapes map values whose keys look like operators
+	wrapped := map[string]interface{}{"literal": map[string]interface{}{"type": "x"}}
+	if _, ok := ParseExpression(wrapped); ok {
+		t.Errorf("Expected a literal map, got an expression")
+	}
+	literal, ok := ParseLiteral(wrapped)
+	if !ok || literal.(map[string]interface{})["type"] != "x" {
+		t.Errorf("Expected the wrapped map, got %v", literal)
+	}
+}
+
+func TestExpression_Evaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression map[string]interface{}
+		actual     pcommon.Value
+		found      bool
+		want       bool
+	}{
+		{"matches", map[string]interface{}{"matches": "^/api/v\\d+"}, pcommon.NewValueStr("/api/v2/users"), true, true},
+		{"not matches", map[string]interface{}{"not_matches": "^/internal"}, pcommon.NewValueStr("/internal/health"), true, false},
+		{"one of", map[string]interface{}{"one_of": []interface{}{"GET", "POST"}}, pcommon.NewValueStr("DELETE"), true, false},
+		{"greater than", map[string]interface{}{"gt": 0}, pcommon.NewValueInt(3), true, true},
+		{"range", map[string]interface{}{"gte": 200, "lt": 300}, pcommon.NewValueInt(302), true, false},
+		{"in range", map[string]interface{}{"in_range": []interface{}{0, 1}}, pcommon.NewValueDouble(0.5), true, true},
+		{"type", map[string]interface{}{"type": "int"}, pcommon.NewValueStr("3"), true, false},
+		{"type alias", map[string]interface{}{"type": "string"}, pcommon.NewValueStr("3"), true, true},
+		{"exists", map[string]interface{}{"exists": true}, pcommon.NewValueEmpty(), false, false},
+		{"absent", map[string]interface{}{"exists": false}, pcommon.NewValueEmpty(), false, true},
+		{"present but must not exist", map[string]interface{}{"not_exists": true}, pcommon.NewValueStr("x"), true, false},
+		{"operator requires presence", map[string]interface{}{"ne": "x"}, pcommon.NewValueEmpty(), false, false},
+		{"not equals", map[string]interface{}{"ne": "x"}, pcommon.NewValueStr("y"), true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, ok := ParseExpression(tt.expression)
+			if !ok {
+				t.Fatalf("Expected an expression")
+			}
+			if err := expression.Validate(); err != nil {
+				t.Fatalf("Expected a valid expression, got %v", err)
+			}
+			got, msg := expression.Evaluate(tt.actual, tt.found, CompareOptions{})
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v (%s)", tt.want, got, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,437 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// pathStepKind identifies what a field path step selects
+type pathStepKind int
+
+const (
+	pathStepField    pathStepKind = iota // .name
+	pathStepKey                          // ["dotted.key"]
+	pathStepIndex                        // [0]
+	pathStepWildcard                     // [*]
+	pathStepFilter                       // [field=value]
+)
+
+// pathStep is a single step of a parsed field path
+type pathStep struct {
+	kind   pathStepKind
+	name   string    // Field name or quoted key
+	index  int       // Element index
+	filter FieldPath // Path evaluated against each element by a filter step
+	value  string    // Value a filter step compares against
+}
+
+// FieldPath is a parsed field path shared by filters, validation rules and pipeline
+// selectors. Paths are dot-separated field names with optional bracket selectors:
+//
+//	spans[*].attributes["http.route"]
+//	resource.attributes.service.name
+//	metrics[name=http.server.duration].datapoints[0].value
+//	logs[*].body.user.id
+//
+// Evaluating a path yields one value per item it reaches. Collections such as spans,
+// metrics, logs and data points fan out over every element, whether or not [*] is
+// written; [n] selects a single element and [field=value] keeps the elements where
+// field equals value. A path ending on an item yields its identifying value, such as
+// a span's name, a log's body or a data point's value. A field missing from an item
+// contributes nil for that item.
+// Attribute keys may contain dots: the longest key present is used, so
+// attributes.http.route finds the key "http.route".
+type FieldPath struct {
+	raw   string
+	steps []pathStep
+}
+
+// ParseFieldPath parses a field path
+func ParseFieldPath(path string) (FieldPath, error) {
+	if strings.TrimSpace(path) == "" {
+		return FieldPath{}, fmt.Errorf("field path is empty")
+	}
+
+	var steps []pathStep
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			if i == 0 || i == len(path)-1 || path[i+1] == '.' || path[i+1] == '[' {
+				return FieldPath{}, fmt.Errorf("invalid field path %q: misplaced '.' at %d", path, i)
+			}
+			i++
+		case '[':
+			end, err := closingBracket(path, i)
+			if err != nil {
+				return FieldPath{}, err
+			}
+			if len(steps) == 0 {
+				return FieldPath{}, fmt.Errorf("invalid field path %q: selector without a field", path)
+			}
+			step, err := parseSelector(path[i+1 : end])
+			if err != nil {
+				return FieldPath{}, fmt.Errorf("invalid field path %q: %w", path, err)
+			}
+			steps = append(steps, step)
+			i = end + 1
+			if i < len(path) && path[i] != '.' && path[i] != '[' {
+				return FieldPath{}, fmt.Errorf("invalid field path %q: expected '.' or '[' after ']' at %d", path, i)
+			}
+		case ']':
+			return FieldPath{}, fmt.Errorf("invalid field path %q: unbalanced ']' at %d", path, i)
+		default:
+			end := i
+			for end < len(path) && path[end] != '.' && path[end] != '[' && path[end] != ']' {
+				end++
+			}
+			steps = append(steps, pathStep{kind: pathStepField, name: path[i:end]})
+			i = end
+		}
+	}
+	return FieldPath{raw: path, steps: steps}, nil
+}
+
+// String returns the path as written
+func (p FieldPath) String() string {
+	return p.raw
+}
+
+// HasWildcard reports whether the path can reach several values through [*] or a filter step
+func (p FieldPath) HasWildcard() bool {
+	for _, step := range p.steps {
+		if step.kind == pathStepWildcard || step.kind == pathStepFilter {
+			return true
+		}
+	}
+	return false
+}
+
+// closingBracket returns the index of the ']' matching the '[' at start, skipping
+// quoted strings and nested selectors
+func closingBracket(path string, start int) (int, error) {
+	depth := 0
+	var quote byte
+	for i := start; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid field path %q: unclosed '[' at %d", path, start)
+}
+
+// parseSelector parses the contents of a bracket selector
+func parseSelector(selector string) (pathStep, error) {
+	selector = strings.TrimSpace(selector)
+	switch {
+	case selector == "":
+		return pathStep{}, fmt.Errorf("empty selector")
+	case selector == "*":
+		return pathStep{kind: pathStepWildcard}, nil
+	case isQuoted(selector):
+		key, err := unquote(selector)
+		if err != nil {
+			return pathStep{}, err
+		}
+		return pathStep{kind: pathStepKey, name: key}, nil
+	}
+
+	if index, err := strconv.Atoi(selector); err == nil {
+		if index < 0 {
+			return pathStep{}, fmt.Errorf("negative index %d", index)
+		}
+		return pathStep{kind: pathStepIndex, index: index}, nil
+	}
+
+	eq := filterSeparator(selector)
+	if eq <= 0 {
+		return pathStep{}, fmt.Errorf("invalid selector [%s]: expected *, an index, a quoted key or field=value", selector)
+	}
+	filter, err := ParseFieldPath(strings.TrimSpace(selector[:eq]))
+	if err != nil {
+		return pathStep{}, err
+	}
+	value := strings.TrimSpace(selector[eq+1:])
+	if isQuoted(value) {
+		if value, err = unquote(value); err != nil {
+			return pathStep{}, err
+		}
+	}
+	return pathStep{kind: pathStepFilter, filter: filter, value: value}, nil
+}
+
+// filterSeparator returns the index of the '=' outside quotes and brackets, or -1
+func filterSeparator(selector string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(selector); i++ {
+		c := selector[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+		case c == '=' && depth == 0:
+			return i
+		}
+	}
+	return -1
+}
+
+// isQuoted reports whether s is wrapped in matching single or double quotes
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0]
+}
+
+// unquote removes the quotes around a key, resolving backslash escapes
+func unquote(s string) (string, error) {
+	inner := s[1 : len(s)-1]
+	var b strings.Builder
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' {
+			if i+1 == len(inner) {
+				return "", fmt.Errorf("invalid key %s: trailing backslash", s)
+			}
+			i++
+		}
+		b.WriteByte(inner[i])
+	}
+	return b.String(), nil
+}
+
+// Evaluate resolves the path against a root value, returning one value per item reached
+func (p FieldPath) Evaluate(root interface{}) []interface{} {
+	return evaluatePath(root, p.steps)
+}
+
+// pathObject is a value with named fields, such as a span or an attribute map node
+type pathObject interface {
+	pathField(name string) (interface{}, bool)
+}
+
+// pathScalar is a value with named fields that also stands for a single value when a
+// path ends on it, such as a span status that yields its code
+type pathScalar interface {
+	pathObject
+	pathValue() interface{}
+}
+
+// pathCollection is a list of items that paths fan out over
+type pathCollection []interface{}
+
+// evaluatePath applies the remaining steps to a node
+func evaluatePath(node interface{}, steps []pathStep) []interface{} {
+	if node == nil {
+		return []interface{}{nil}
+	}
+	if collection, ok := node.(pathCollection); ok && len(steps) == 0 {
+		values := make([]interface{}, 0, len(collection))
+		for _, element := range collection {
+			values = append(values, leafValue(element))
+		}
+		return values
+	}
+	if len(steps) == 0 {
+		return []interface{}{leafValue(node)}
+	}
+
+	step := steps[0]
+	if collection, ok := node.(pathCollection); ok && (step.kind == pathStepField || step.kind == pathStepKey) {
+		// Field access on a collection applies to every element
+		var values []interface{}
+		for _, element := range collection {
+			values = append(values, evaluatePath(element, steps)...)
+		}
+		return values
+	}
+
+	switch step.kind {
+	case pathStepField:
+		child, consumed := resolveField(node, steps)
+		return evaluatePath(child, steps[consumed:])
+	case pathStepKey:
+		child, _ := lookupField(node, step.name)
+		return evaluatePath(child, steps[1:])
+	case pathStepIndex:
+		elements, ok := pathElements(node)
+		if !ok || step.index >= len(elements) {
+			return []interface{}{nil}
+		}
+		return evaluatePath(elements[step.index], steps[1:])
+	case pathStepWildcard, pathStepFilter:
+		elements, ok := pathElements(node)
+		if !ok {
+			return []interface{}{nil}
+		}
+		var values []interface{}
+		for _, element := range elements {
+			if step.kind == pathStepFilter && !filterMatches(step, element) {
+				continue
+			}
+			values = append(values, evaluatePath(element, steps[1:])...)
+		}
+		return values
+	}
+	return []interface{}{nil}
+}
+
+// resolveField resolves a run of field steps against a node. Keyed values such as
+// attribute maps try the longest dotted key first; the number of steps consumed is returned.
+func resolveField(node interface{}, steps []pathStep) (interface{}, int) {
+	run := 1
+	for run < len(steps) && steps[run].kind == pathStepField {
+		run++
+	}
+	if _, ok := node.(pathObject); ok {
+		run = 1
+	}
+
+	for n := run; n >= 1; n-- {
+		names := make([]string, 0, n)
+		for _, step := range steps[:n] {
+			names = append(names, step.name)
+		}
+		if child, ok := lookupField(node, strings.Join(names, ".")); ok {
+			return child, n
+		}
+	}
+	return nil, 1
+}
+
+// lookupField returns the named child of an object or map
+func lookupField(node interface{}, name string) (interface{}, bool) {
+	switch value := node.(type) {
+	case pathObject:
+		return value.pathField(name)
+	case pcommon.Map:
+		child, ok := value.Get(name)
+		if !ok {
+			return nil, false
+		}
+		return child, true
+	case pcommon.Value:
+		if value.Type() == pcommon.ValueTypeMap {
+			return lookupField(value.Map(), name)
+		}
+	case map[string]interface{}:
+		child, ok := value[name]
+		return child, ok
+	case map[string]string:
+		child, ok := value[name]
+		return child, ok
+	}
+	return nil, false
+}
+
+// pathElements returns the elements of a collection, slice or map
+func pathElements(node interface{}) ([]interface{}, bool) {
+	switch value := node.(type) {
+	case pathCollection:
+		return value, true
+	case []interface{}:
+		return value, true
+	case pcommon.Slice:
+		elements := make([]interface{}, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			elements = append(elements, value.At(i))
+		}
+		return elements, true
+	case pcommon.Value:
+		switch value.Type() {
+		case pcommon.ValueTypeSlice:
+			return pathElements(value.Slice())
+		case pcommon.ValueTypeMap:
+			return pathElements(value.Map())
+		}
+	case pcommon.Map:
+		keys := make([]string, 0, value.Len())
+		value.Range(func(k string, _ pcommon.Value) bool {
+			keys = append(keys, k)
+			return true
+		})
+		sort.Strings(keys)
+		elements := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			child, _ := value.Get(key)
+			elements = append(elements, child)
+		}
+		return elements, true
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		elements := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			elements = append(elements, value[key])
+		}
+		return elements, true
+	case map[string]string:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		elements := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			elements = append(elements, value[key])
+		}
+		return elements, true
+	}
+	return nil, false
+}
+
+// filterMatches reports whether any value of the filter path equals the filter value
+func filterMatches(step pathStep, element interface{}) bool {
+	for _, value := range step.filter.Evaluate(element) {
+		if value != nil && stringify(value) == step.value {
+			return true
+		}
+	}
+	return false
+}
+
+// leafValue converts the node a path ends on into a plain Go value
+func leafValue(node interface{}) interface{} {
+	switch value := node.(type) {
+	case pathScalar:
+		return value.pathValue()
+	case pcommon.Value:
+		return ValueToInterface(value)
+	case pcommon.Map:
+		return value.AsRaw()
+	case pcommon.Slice:
+		return value.AsRaw()
+	}
+	return node
+}
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// newFieldPathTestData builds two spans, two metrics with data points and two logs
+func newFieldPathTestData() OpenTelemetryData {
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := resourceSpans.ScopeSpans().AppendEmpty().Spans()
+	for _, route := range []string{"/cart", "/pay"} {
+		span := spans.AppendEmpty()
+		span.SetName("GET " + route)
+		span.Attributes().PutStr("http.route", route)
+	}
+	spans.At(1).Status().SetCode(ptrace.StatusCodeError)
+	spans.At(1).Events().AppendEmpty().SetName("exception")
+
+	metrics := pmetric.NewMetrics()
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	requests := scopeMetrics.Metrics().AppendEmpty()
+	requests.SetName("http.server.requests")
+	requests.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(7)
+	duration := scopeMetrics.Metrics().AppendEmpty()
+	duration.SetName("http.server.duration")
+	gauge := duration.SetEmptyGauge()
+	for i, method := range []string{"GET", "POST"} {
+		dp := gauge.DataPoints().AppendEmpty()
+		dp.SetDoubleValue(float64(i+1) * 0.25)
+		dp.Attributes().PutStr("method", method)
+	}
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, id := range []string{"u-1", "u-2"} {
+		body := records.AppendEmpty().Body().SetEmptyMap()
+		body.PutEmptyMap("user").PutStr("id", id)
+	}
+
+	return OpenTelemetryData{Traces: traces, Metrics: metrics, Logs: logs}
+}
+
+func TestFieldPath_Evaluate(t *testing.T) {
+	data := newFieldPathTestData()
+
+	tests := []struct {
+		path string
+		want []interface{}
+	}{
+		{`spans[*].attributes["http.route"]`, []interface{}{"/cart", "/pay"}},
+		{`spans.attributes.http.route`, []interface{}{"/cart", "/pay"}},
+		{`span.name`, []interface{}{"GET /cart", "GET /pay"}},
+		{`spans[1].name`, []interface{}{"GET /pay"}},
+		{`spans[5].name`, []interface{}{nil}},
+		{`spans[name="GET /pay"].status`, []interface{}{"Error"}},
+		{`spans.status.code`, []interface{}{"Unset", "Error"}},
+		{`span.service.name`, []interface{}{"checkout", "checkout"}},
+		{`spans.events.name`, []interface{}{"exception"}},
+		{`spans.attributes.missing`, []interface{}{nil, nil}},
+		{`resource.attributes.service.name`, []interface{}{"checkout", nil, nil}},
+		{`metrics[name=http.server.duration].datapoints[0].value`, []interface{}{0.25}},
+		{`metrics[name=http.server.duration].datapoints.value`, []interface{}{0.25, 0.5}},
+		{`metrics[name=http.server.requests].value`, []interface{}{int64(7)}},
+		{`metric.labels.method`, []interface{}{nil, "GET", "POST"}},
+		{`metrics[name="http.server.duration"].datapoints[attributes.method=POST].value`, []interface{}{0.5}},
+		{`logs[*].body.user.id`, []interface{}{"u-1", "u-2"}},
+		{`logs[1].body["user"].id`, []interface{}{"u-2"}},
+		{`unknown.field`, []interface{}{nil}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := ParseFieldPath(tt.path)
+			if err != nil {
+				t.Fatalf("ParseFieldPath failed: %v", err)
+			}
+			got := path.Evaluate(telemetryRoot{data: data})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFieldPath_Maps(t *testing.T) {
+	root := map[string]interface{}{
+		"name": "traces",
+		"tags": map[string]string{"environment": "prod", "team.owner": "payments"},
+	}
+
+	tests := []struct {
+		path string
+		want []interface{}
+	}{
+		{"name", []interface{}{"traces"}},
+		{"tags.environment", []interface{}{"prod"}},
+		{"tags.team.owner", []interface{}{"payments"}},
+		{`tags["team.owner"]`, []interface{}{"payments"}},
+		{"tags[*]", []interface{}{"prod", "payments"}},
+		{"tags.missing", []interface{}{nil}},
+	}
+
+	for _, tt := range tests {
+		path, err := ParseFieldPath(tt.path)
+		if err != nil {
+			t.Fatalf("ParseFieldPath(%q) failed: %v", tt.path, err)
+		}
+		if got := path.Evaluate(root); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestParseFieldPath_Invalid(t *testing.T) {
+	for _, path := range []string{
+		"",
+		"spans..name",
+		".spans",
+		"spans.",
+		"[0].name",
+		"spans[",
+		"spans]",
+		"spans[]",
+		"spans[-1]",
+		"spans[name]",
+		"spans[0]name",
+		`spans.attributes["http.route]`,
+	} {
+		if _, err := ParseFieldPath(path); err == nil {
+			t.Errorf("Expected %q to be rejected", path)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// negatedOperators maps each negated filter operator to its positive counterpart
+var negatedOperators = map[FilterOperator]FilterOperator{
+	FilterOperatorNotEquals:   FilterOperatorEquals,
+	FilterOperatorNotMatches:  FilterOperatorMatches,
+	FilterOperatorNotExists:   FilterOperatorExists,
+	FilterOperatorNotContains: FilterOperatorContains,
+	FilterOperatorNotInRange:  FilterOperatorInRange,
+	FilterOperatorNotOneOf:    FilterOperatorOneOf,
+}
+
+// ApplyFilters reports whether every filter holds for the data.
+// The returned string describes the first filter that did not hold.
+func ApplyFilters(filters []Filter, data OpenTelemetryData) (bool, string, error) {
+	for i, filter := range filters {
+		ok, err := EvaluateFilter(filter, data)
+		if err != nil {
+			return false, "", fmt.Errorf("filter %d (%s %s): %w", i, filter.Field, filter.Operator, err)
+		}
+		if !ok {
+			return false, fmt.Sprintf("filter %d did not match: %s %s %v", i, filter.Field, filter.Operator, filter.Value), nil
+		}
+	}
+	return true, "", nil
+}
+
+// EvaluateFilter evaluates a single filter against the data.
+// Positive operators hold when any item's field satisfies them; negated
+// operators hold when no item's field satisfies the positive form.
+func EvaluateFilter(filter Filter, data OpenTelemetryData) (bool, error) {
+	values := ExtractFieldValues(filter.Field, data)
+	if len(values) == 0 {
+		values = []interface{}{nil}
+	}
+
+	operator, negated := filter.Operator, false
+	if positive, ok := negatedOperators[operator]; ok {
+		operator, negated = positive, true
+	}
+
+	matched := false
+	for _, value := range values {
+		ok, err := EvaluateOperator(operator, value, filter.Value)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			matched = true
+			break
+		}
+	}
+
+	return matched != negated, nil
+}
+
+// EvaluateOperator applies a filter operator to an actual value and an operand.
+// For in_range the operand is a ValueRange, a {min, max} mapping or a [min, max]
+// list; for one_of it is a list of allowed values.
+func EvaluateOperator(operator FilterOperator, actual, operand interface{}) (bool, error) {
+	if positive, ok := negatedOperators[operator]; ok {
+		result, err := EvaluateOperator(positive, actual, operand)
+		return !result, err
+	}
+
+	switch operator {
+	case FilterOperatorExists:
+		return actual != nil, nil
+	}
+
+	if actual == nil {
+		return false, nil
+	}
+
+	switch operator {
+	case FilterOperatorEquals:
+		return ValuesEqual(actual, operand), nil
+	case FilterOperatorGreaterThan, FilterOperatorLessThan,
+		FilterOperatorGreaterOrEqual, FilterOperatorLessOrEqual:
+		return compareOrdered(actual, operand, operator), nil
+	case FilterOperatorMatches:
+		pattern, ok := operand.(string)
+		if !ok {
+			return false, fmt.Errorf("matches requires a string pattern, got %T", operand)
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		return regex.MatchString(stringify(actual)), nil
+	case FilterOperatorContains:
+		if items, ok := actual.([]interface{}); ok {
+			for _, item := range items {
+				if ValuesEqual(item, operand) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return strings.Contains(stringify(actual), stringify(operand)), nil
+	case FilterOperatorStartsWith:
+		return strings.HasPrefix(stringify(actual), stringify(operand)), nil
+	case FilterOperatorEndsWith:
+		return strings.HasSuffix(stringify(actual), stringify(operand)), nil
+	case FilterOperatorInRange:
+		valueRange, err := ParseValueRange(operand)
+		if err != nil {
+			return false, err
+		}
+		return InRange(actual, valueRange), nil
+	case FilterOperatorOneOf:
+		options, ok := operand.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("one_of requires a list of values, got %T", operand)
+		}
+		for _, option := range options {
+			if ValuesEqual(actual, option) {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported operator %s", operator)
+	}
+}
+
+// ValuesEqual compares two values, numerically when both are numbers and by
+// their string form otherwise
+func ValuesEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if isNumber(a) && isNumber(b) {
+		af, _ := ToNumeric(a)
+		bf, _ := ToNumeric(b)
+		return math.Abs(af-bf) < 1e-9
+	}
+	return stringify(a) == stringify(b)
+}
+
+// compareOrdered compares two values numerically when possible and lexically otherwise
+func compareOrdered(a, b interface{}, operator FilterOperator) bool {
+	af, aErr := ToNumeric(a)
+	bf, bErr := ToNumeric(b)
+	if aErr == nil && bErr == nil {
+		return compareFloats(af, bf, operator)
+	}
+
+	as, aOk := a.(string)
+	bs, bOk := b.(string)
+	if !aOk || !bOk {
+		return false
+	}
+	switch operator {
+	case FilterOperatorGreaterThan:
+		return as > bs
+	case FilterOperatorLessThan:
+		return as < bs
+	case FilterOperatorGreaterOrEqual:
+		return as >= bs
+	case FilterOperatorLessOrEqual:
+		return as <= bs
+	}
+	return false
+}
+
+// compareFloats compares two numbers using an ordering operator
+func compareFloats(a, b float64, operator FilterOperator) bool {
+	switch operator {
+	case FilterOperatorGreaterThan:
+		return a > b
+	case FilterOperatorLessThan:
+		return a < b
+	case FilterOperatorGreaterOrEqual:
+		return a >= b
+	case FilterOperatorLessOrEqual:
+		return a <= b
+	}
+	return false
+}
+
+// ToNumeric converts a numeric or numeric string value to float64
+func ToNumeric(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to numeric", value)
+	}
+}
+
+// isNumber reports whether a value has a numeric Go type
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int32, int64, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
+// stringify returns the string form of a value
+func stringify(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ParseValueRange converts a filter operand into a value range
+func ParseValueRange(operand interface{}) (ValueRange, error) {
+	switch v := operand.(type) {
+	case ValueRange:
+		return v, nil
+	case *ValueRange:
+		if v == nil {
+			return ValueRange{}, fmt.Errorf("range is nil")
+		}
+		return *v, nil
+	case []interface{}:
+		if len(v) != 2 {
+			return ValueRange{}, fmt.Errorf("range list must have exactly two elements [min, max]")
+		}
+		return ValueRange{Min: v[0], Max: v[1], Inclusive: true}, nil
+	case map[string]interface{}:
+		valueRange := ValueRange{Min: v["min"], Max: v["max"]}
+		if inclusive, ok := v["inclusive"].(bool); ok {
+			valueRange.Inclusive = inclusive
+		}
+		if minInclusive, ok := v["min_inclusive"].(bool); ok {
+			valueRange.MinInclusive = &minInclusive
+		}
+		if maxInclusive, ok := v["max_inclusive"].(bool); ok {
+			valueRange.MaxInclusive = &maxInclusive
+		}
+		if valueRange.Min == nil && valueRange.Max == nil {
+			return ValueRange{}, fmt.Errorf("range must specify min or max")
+		}
+		return valueRange, nil
+	default:
+		return ValueRange{}, fmt.Errorf("range must be a {min, max} mapping or a [min, max] list, got %T", operand)
+	}
+}
+
+// InRange reports whether a numeric value falls within a value range
+func InRange(value interface{}, valueRange ValueRange) bool {
+	numValue, err := ToNumeric(value)
+	if err != nil {
+		return false
+	}
+
+	// Check minimum
+	if valueRange.Min != nil {
+		minValue, err := ToNumeric(valueRange.Min)
+		if err != nil {
+			return false
+		}
+
+		minInclusive := valueRange.Inclusive
+		if valueRange.MinInclusive != nil {
+			minInclusive = *valueRange.MinInclusive
+		}
+
+		if numValue < minValue || (!minInclusive && numValue == minValue) {
+			return false
+		}
+	}
+
+	// Check maximum
+	if valueRange.Max != nil {
+		maxValue, err := ToNumeric(valueRange.Max)
+		if err != nil {
+			return false
+		}
+
+		maxInclusive := valueRange.Inclusive
+		if valueRange.MaxInclusive != nil {
+			maxInclusive = *valueRange.MaxInclusive
+		}
+
+		if numValue > maxValue || (!maxInclusive && numValue == maxValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ExtractFieldValues evaluates a field path against the data, returning one value per
+// item the path reaches. Items without the field contribute nil and invalid paths yield
+// no values. Supported roots are spans, metrics, logs and resource, along with the
+// singular span, metric and log forms.
+func ExtractFieldValues(fieldPath string, data OpenTelemetryData) []interface{} {
+	path, err := ParseFieldPath(fieldPath)
+	if err != nil {
+		return nil
+	}
+	return path.Evaluate(telemetryRoot{data: data})
+}
+
+// MetricTypeName returns the contract name for a metric's type
+func MetricTypeName(metric pmetric.Metric) string {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return "gauge"
+	case pmetric.MetricTypeSum:
+		// Check if it's a counter (monotonic sum) or regular sum
+		if metric.Sum().IsMonotonic() {
+			return "counter"
+		}
+		return "sum"
+	case pmetric.MetricTypeHistogram:
+		return "histogram"
+	case pmetric.MetricTypeExponentialHistogram:
+		return "exponential_histogram"
+	case pmetric.MetricTypeSummary:
+		return "summary"
+	default:
+		return ""
+	}
+}
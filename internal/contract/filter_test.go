@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// newFilterTestData builds data with two spans, one metric and one log record
+func newFilterTestData() OpenTelemetryData {
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := resourceSpans.ScopeSpans().AppendEmpty().Spans()
+	for i, name := range []string{"GET /cart", "POST /cart"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.Attributes().PutInt("http.status_code", int64(200+i*300))
+	}
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("requests_total")
+	metric.SetEmptySum().DataPoints().AppendEmpty().Attributes().PutStr("method", "GET")
+
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.Body().SetStr("payment accepted")
+	record.SetSeverityText("INFO")
+
+	return OpenTelemetryData{Traces: traces, Metrics: metrics, Logs: logs}
+}
+
+func TestEvaluateFilter(t *testing.T) {
+	data := newFilterTestData()
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"equals any span", Filter{Field: "span.name", Operator: FilterOperatorEquals, Value: "POST /cart"}, true},
+		{"not equals holds for no span", Filter{Field: "span.name", Operator: FilterOperatorNotEquals, Value: "POST /cart"}, false},
+		{"not equals absent value", Filter{Field: "span.name", Operator: FilterOperatorNotEquals, Value: "DELETE /cart"}, true},
+		{"matches", Filter{Field: "span.name", Operator: FilterOperatorMatches, Value: "^GET "}, true},
+		{"not matches", Filter{Field: "span.name", Operator: FilterOperatorNotMatches, Value: "^PUT "}, true},
+		{"dotted attribute key", Filter{Field: "span.attributes.http.status_code", Operator: FilterOperatorGreaterOrEqual, Value: 500}, true},
+		{"resource attribute", Filter{Field: "span.resource.attributes.service.name", Operator: FilterOperatorEquals, Value: "checkout"}, true},
+		{"in range list", Filter{Field: "span.attributes.http.status_code", Operator: FilterOperatorInRange, Value: []interface{}{200, 299}}, true},
+		{"in range mapping", Filter{Field: "span.attributes.http.status_code", Operator: FilterOperatorInRange, Value: map[string]interface{}{"min": 300, "max": 400}}, false},
+		{"not in range", Filter{Field: "span.attributes.http.status_code", Operator: FilterOperatorNotInRange, Value: []interface{}{100, 199}}, true},
+		{"one of", Filter{Field: "metric.labels.method", Operator: FilterOperatorOneOf, Value: []interface{}{"GET", "HEAD"}}, true},
+		{"not one of", Filter{Field: "log.severity", Operator: FilterOperatorNotOneOf, Value: []interface{}{"ERROR", "FATAL"}}, true},
+		{"exists", Filter{Field: "span.attributes.http.status_code", Operator: FilterOperatorExists}, true},
+		{"not exists", Filter{Field: "span.attributes.user.id", Operator: FilterOperatorNotExists}, true},
+		{"contains", Filter{Field: "log.body", Operator: FilterOperatorContains, Value: "payment"}, true},
+		{"not contains", Filter{Field: "log.body", Operator: FilterOperatorNotContains, Value: "payment"}, false},
+		{"starts with", Filter{Field: "metric.name", Operator: FilterOperatorStartsWith, Value: "requests"}, true},
+		{"ends with", Filter{Field: "metric.name", Operator: FilterOperatorEndsWith, Value: "_seconds"}, false},
+		{"unknown field", Filter{Field: "span.unknown", Operator: FilterOperatorEquals, Value: "x"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateFilter(tt.filter, data)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEvaluateFilter_InvalidOperand(t *testing.T) {
+	data := newFilterTestData()
+
+	filters := []Filter{
+		{Field: "span.name", Operator: FilterOperatorMatches, Value: "("},
+		{Field: "span.attributes.http.status_code", Operator: FilterOperatorInRange, Value: "200-299"},
+		{Field: "span.name", Operator: FilterOperatorOneOf, Value: "GET /cart"},
+	}
+
+	for _, filter := range filters {
+		if _, err := EvaluateFilter(filter, data); err == nil {
+			t.Errorf("Expected error for %s %s %v", filter.Field, filter.Operator, filter.Value)
+		}
+	}
+}
+
+func TestContract_ValidateSkipsFilteredContracts(t *testing.T) {
+	data := newFilterTestData()
+	contract := &Contract{
+		Publisher: "checkout",
+		Pipeline:  "traces",
+		Version:   "1.0.0",
+		Inputs: Inputs{
+			Traces: []TraceInput{{SpanName: "GET /cart"}},
+		},
+		Matchers: Matchers{
+			Traces: []TraceMatcher{{SpanName: "GET /cart"}},
+		},
+		Filters: []Filter{
+			{Field: "span.name", Operator: FilterOperatorEquals, Value: "DELETE /cart"},
+		},
+	}
+
+	result := contract.Validate(data, data)
+	if !result.Skipped {
+		t.Fatalf("Expected contract to be skipped")
+	}
+	if result.SkipReason == "" {
+		t.Errorf("Expected a skip reason")
+	}
+
+	contract.Filters[0].Value = "GET /cart"
+	result = contract.Validate(data, data)
+	if result.Skipped {
+		t.Errorf("Expected contract not to be skipped")
+	}
+
+	contract.Filters[0].Operator = FilterOperatorMatches
+	contract.Filters[0].Value = "["
+	result = contract.Validate(data, data)
+	if result.Valid || len(result.Errors) == 0 || result.Errors[0].Type != "filter" {
+		t.Errorf("Expected filter error, got %+v", result)
+	}
+}
@@ -4,13 +4,17 @@
 package contract
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/goedelsoup/waveform/internal/semconv"
 	"gopkg.in/yaml.v3"
 )
 
@@ -129,6 +133,9 @@ func (l *Loader) validateContract(contract *Contract) error {
 	if contract.Version == "" {
 		errors = append(errors, "version is required")
 	}
+	if _, err := contract.GetTimeout(); err != nil {
+		errors = append(errors, err.Error())
+	}
 
 	// Validate pipeline configuration
 	if err := l.validatePipelineConfig(contract); err != nil {
@@ -146,10 +153,22 @@ func (l *Loader) validateContract(contract *Contract) error {
 	}
 
 	// Validate matchers
-	if err := l.validateMatchers(&contract.Matchers); err != nil {
+	if !contract.hasMatchers() {
+		errors = append(errors, "matchers validation failed: at least one matcher type (traces, metrics, logs, trace_structure, expect, golden, semconv, cardinality, correlations, or batches) must be specified")
+	} else if err := l.validateMatchers(&contract.Matchers); err != nil {
 		errors = append(errors, fmt.Sprintf("matchers validation failed: %v", err))
 	}
 
+	// Validate span link references
+	if err := l.validateLinkReferences(contract); err != nil {
+		errors = append(errors, fmt.Sprintf("links validation failed: %v", err))
+	}
+
+	// Validate span references from logs, exemplars and correlations
+	if err := l.validateCorrelationReferences(contract); err != nil {
+		errors = append(errors, fmt.Sprintf("correlations validation failed: %v", err))
+	}
+
 	// Validate time windows
 	if err := l.validateTimeWindows(contract.TimeWindows); err != nil {
 		errors = append(errors, fmt.Sprintf("time_windows validation failed: %v", err))
@@ -197,6 +216,12 @@ func (l *Loader) validatePipelineSelectors(selectors *PipelineSelectors) error {
 		}
 	}
 
+	switch selectors.Match {
+	case "", PipelineMatchAll, PipelineMatchBest, PipelineMatchExactlyOne:
+	default:
+		return fmt.Errorf("invalid match policy %q (must be best, all, or exactly_one)", selectors.Match)
+	}
+
 	return nil
 }
 
@@ -205,6 +230,9 @@ func (l *Loader) validatePipelineSelector(selector PipelineSelector, index int)
 	if selector.Field == "" {
 		return fmt.Errorf("field is required")
 	}
+	if _, err := ParseFieldPath(selector.Field); err != nil {
+		return err
+	}
 
 	switch selector.Operator {
 	case PipelineSelectorOperatorEquals, PipelineSelectorOperatorMatches,
@@ -232,6 +260,19 @@ func (l *Loader) validateInputs(inputs *Inputs) error {
 		if trace.SpanName == "" {
 			return fmt.Errorf("trace input %d: span_name is required", i)
 		}
+		if err := l.validateExpectMode(trace.Expect); err != nil {
+			return fmt.Errorf("trace input %d: %w", i, err)
+		}
+		for j, event := range trace.Events {
+			if event.Name == "" {
+				return fmt.Errorf("trace input %d: event %d: name is required", i, j)
+			}
+		}
+		for j, link := range trace.Links {
+			if link.Span == "" {
+				return fmt.Errorf("trace input %d: link %d: span is required", i, j)
+			}
+		}
 	}
 
 	// Validate metric inputs
@@ -242,6 +283,14 @@ func (l *Loader) validateInputs(inputs *Inputs) error {
 		if metric.Value == nil {
 			return fmt.Errorf("metric input %d: value is required", i)
 		}
+		if err := l.validateExpectMode(metric.Expect); err != nil {
+			return fmt.Errorf("metric input %d: %w", i, err)
+		}
+		for j, exemplar := range metric.Exemplars {
+			if exemplar.Span == "" {
+				return fmt.Errorf("metric input %d: exemplar %d: span is required", i, j)
+			}
+		}
 	}
 
 	// Validate log inputs
@@ -249,6 +298,9 @@ func (l *Loader) validateInputs(inputs *Inputs) error {
 		if log.Body == "" {
 			return fmt.Errorf("log input %d: body is required", i)
 		}
+		if err := l.validateExpectMode(log.Expect); err != nil {
+			return fmt.Errorf("log input %d: %w", i, err)
+		}
 	}
 
 	return nil
@@ -260,6 +312,9 @@ func (l *Loader) validateFilters(filters []Filter) error {
 		if filter.Field == "" {
 			return fmt.Errorf("filter %d: field is required", i)
 		}
+		if _, err := ParseFieldPath(filter.Field); err != nil {
+			return fmt.Errorf("filter %d: %w", i, err)
+		}
 
 		switch filter.Operator {
 		case FilterOperatorEquals, FilterOperatorNotEquals, FilterOperatorMatches,
@@ -271,6 +326,9 @@ func (l *Loader) validateFilters(filters []Filter) error {
 			if filter.Value == nil {
 				return fmt.Errorf("filter %d: value is required for operator %s", i, filter.Operator)
 			}
+			if err := validateFilterOperand(filter); err != nil {
+				return fmt.Errorf("filter %d: %w", i, err)
+			}
 		case FilterOperatorExists, FilterOperatorNotExists:
 			// Value is optional for exists/not_exists
 		default:
@@ -280,35 +338,529 @@ func (l *Loader) validateFilters(filters []Filter) error {
 	return nil
 }
 
+// validateFilterOperand checks that a filter value has the shape its operator expects
+func validateFilterOperand(filter Filter) error {
+	switch filter.Operator {
+	case FilterOperatorMatches, FilterOperatorNotMatches:
+		pattern, ok := filter.Value.(string)
+		if !ok {
+			return fmt.Errorf("operator %s requires a string pattern", filter.Operator)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	case FilterOperatorInRange, FilterOperatorNotInRange:
+		if _, err := ParseValueRange(filter.Value); err != nil {
+			return err
+		}
+	case FilterOperatorOneOf, FilterOperatorNotOneOf:
+		if _, ok := filter.Value.([]interface{}); !ok {
+			return fmt.Errorf("operator %s requires a list of values", filter.Operator)
+		}
+	}
+	return nil
+}
+
 // validateMatchers validates the matchers section
 func (l *Loader) validateMatchers(matchers *Matchers) error {
-	// At least one matcher type should be specified
-	if len(matchers.Traces) == 0 && len(matchers.Metrics) == 0 && len(matchers.Logs) == 0 {
-		return fmt.Errorf("at least one matcher type (traces, metrics, or logs) must be specified")
+	if matchers.Expect != nil {
+		if err := l.validateExpectations(matchers.Expect); err != nil {
+			return fmt.Errorf("expect: %w", err)
+		}
+	}
+
+	if matchers.Golden != nil {
+		if err := l.validateGolden(matchers.Golden); err != nil {
+			return fmt.Errorf("golden: %w", err)
+		}
+	}
+
+	if matchers.Semconv != nil {
+		if err := l.validateSemconv(matchers.Semconv); err != nil {
+			return fmt.Errorf("semconv: %w", err)
+		}
+	}
+
+	for i, matcher := range matchers.Correlations {
+		if err := l.validateCorrelation(matcher); err != nil {
+			return fmt.Errorf("correlation %d: %w", i, err)
+		}
+	}
+
+	for i, matcher := range matchers.Cardinality {
+		if err := l.validateCardinality(matcher); err != nil {
+			return fmt.Errorf("cardinality %d: %w", i, err)
+		}
+	}
+
+	if matchers.Batches != nil {
+		if err := l.validateBatches(matchers.Batches); err != nil {
+			return fmt.Errorf("batches: %w", err)
+		}
+	}
+
+	if err := l.validateTraceStructure(matchers.TraceStructure); err != nil {
+		return fmt.Errorf("trace_structure: %w", err)
+	}
+
+	if matchers.Comparison != nil && matchers.Comparison.Tolerance < 0 {
+		return fmt.Errorf("comparison: tolerance must not be negative")
 	}
 
 	// Validate trace matchers
 	for i, matcher := range matchers.Traces {
 		if matcher.SpanName == "" && len(matcher.Attributes) == 0 &&
-			matcher.ParentSpan == "" && matcher.ServiceName == "" {
+			matcher.ParentSpan == "" && matcher.ServiceName == "" &&
+			matcher.Count == nil && matcher.Duration == nil && matcher.StatusCode == nil &&
+			matcher.Resource == nil && matcher.Scope == nil &&
+			len(matcher.Events) == 0 && len(matcher.Links) == 0 {
 			return fmt.Errorf("trace matcher %d: at least one field must be specified", i)
 		}
+		if err := l.validateSpanRelations(matcher.Events, matcher.Links); err != nil {
+			return fmt.Errorf("trace matcher %d: %w", i, err)
+		}
+		if err := l.validateCountMatcher(matcher.Count); err != nil {
+			return fmt.Errorf("trace matcher %d: %w", i, err)
+		}
+		if err := l.validateDurationMatcher(matcher.Duration); err != nil {
+			return fmt.Errorf("trace matcher %d: %w", i, err)
+		}
+		if err := l.validateStatusCodeMatcher(matcher.StatusCode); err != nil {
+			return fmt.Errorf("trace matcher %d: %w", i, err)
+		}
+		if err := l.validateQuantifier(matcher.Quantifier); err != nil {
+			return fmt.Errorf("trace matcher %d: %w", i, err)
+		}
+		if err := l.validateAttributeExpressions("attributes", matcher.Attributes); err != nil {
+			return fmt.Errorf("trace matcher %d: %w", i, err)
+		}
+		if err := l.validateResourceAndScope(matcher.Resource, matcher.Scope); err != nil {
+			return fmt.Errorf("trace matcher %d: %w", i, err)
+		}
 	}
 
 	// Validate metric matchers
 	for i, matcher := range matchers.Metrics {
-		if matcher.Name == "" && len(matcher.Labels) == 0 && matcher.Type == "" {
+		if matcher.Name == "" && len(matcher.Labels) == 0 && matcher.Type == "" &&
+			matcher.Value == nil && matcher.Count == nil && matcher.Histogram == nil &&
+			matcher.Resource == nil && matcher.Scope == nil {
 			return fmt.Errorf("metric matcher %d: at least one field must be specified", i)
 		}
+		if err := l.validateCountMatcher(matcher.Count); err != nil {
+			return fmt.Errorf("metric matcher %d: %w", i, err)
+		}
+		if err := l.validateQuantifier(matcher.Quantifier); err != nil {
+			return fmt.Errorf("metric matcher %d: %w", i, err)
+		}
+		if err := l.validateAttributeExpressions("labels", matcher.Labels); err != nil {
+			return fmt.Errorf("metric matcher %d: %w", i, err)
+		}
+		if err := l.validateResourceAndScope(matcher.Resource, matcher.Scope); err != nil {
+			return fmt.Errorf("metric matcher %d: %w", i, err)
+		}
 	}
 
 	// Validate log matchers
 	for i, matcher := range matchers.Logs {
-		if matcher.Body == "" && len(matcher.Attributes) == 0 && matcher.Severity == "" {
+		if matcher.Body == "" && matcher.BodyMatches == "" && len(matcher.BodyFields) == 0 &&
+			len(matcher.Attributes) == 0 && matcher.Severity.IsZero() &&
+			matcher.Count == nil && matcher.Timestamp == nil &&
+			matcher.Resource == nil && matcher.Scope == nil {
 			return fmt.Errorf("log matcher %d: at least one field must be specified", i)
 		}
+		if err := l.validateLogBody(matcher); err != nil {
+			return fmt.Errorf("log matcher %d: %w", i, err)
+		}
+		if err := matcher.Severity.Validate(); err != nil {
+			return fmt.Errorf("log matcher %d: severity: %w", i, err)
+		}
+		if err := l.validateCountMatcher(matcher.Count); err != nil {
+			return fmt.Errorf("log matcher %d: %w", i, err)
+		}
+		if err := l.validateTimestampMatcher(matcher.Timestamp); err != nil {
+			return fmt.Errorf("log matcher %d: %w", i, err)
+		}
+		if err := l.validateQuantifier(matcher.Quantifier); err != nil {
+			return fmt.Errorf("log matcher %d: %w", i, err)
+		}
+		if err := l.validateAttributeExpressions("attributes", matcher.Attributes); err != nil {
+			return fmt.Errorf("log matcher %d: %w", i, err)
+		}
+		if err := l.validateResourceAndScope(matcher.Resource, matcher.Scope); err != nil {
+			return fmt.Errorf("log matcher %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateAttributeExpressions validates inline operator expressions in an attribute map
+func (l *Loader) validateAttributeExpressions(section string, attributes map[string]interface{}) error {
+	for key, value := range attributes {
+		expression, ok := ParseExpression(value)
+		if !ok {
+			continue
+		}
+		if err := expression.Validate(); err != nil {
+			return fmt.Errorf("%s %s: %w", section, key, err)
+		}
+	}
+	return nil
+}
+
+// validateResourceAndScope validates resource and scope matchers
+func (l *Loader) validateResourceAndScope(resource *ResourceMatcher, scope *ScopeMatcher) error {
+	if resource != nil {
+		if len(resource.Attributes) == 0 && resource.SchemaURL == "" {
+			return fmt.Errorf("resource: at least one field must be specified")
+		}
+		if err := l.validateAttributeExpressions("resource attributes", resource.Attributes); err != nil {
+			return err
+		}
 	}
+	if scope != nil {
+		if scope.Name == "" && scope.Version == "" && len(scope.Attributes) == 0 && scope.SchemaURL == "" {
+			return fmt.Errorf("scope: at least one field must be specified")
+		}
+		if err := l.validateAttributeExpressions("scope attributes", scope.Attributes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// validateTraceStructure validates trace structure assertions
+func (l *Loader) validateTraceStructure(structure *TraceStructureMatcher) error {
+	if structure == nil {
+		return nil
+	}
+	if err := l.validateCountMatcher(structure.TraceCount); err != nil {
+		return fmt.Errorf("trace_count: %w", err)
+	}
+	if err := l.validateCountMatcher(structure.SpansPerTrace); err != nil {
+		return fmt.Errorf("spans_per_trace: %w", err)
+	}
+	for i, edge := range structure.Edges {
+		if edge.Parent == "" || edge.Child == "" {
+			return fmt.Errorf("edge %d: parent and child are required", i)
+		}
+	}
+	if structure.MaxDepth < 0 {
+		return fmt.Errorf("max_depth must not be negative")
+	}
+	return nil
+}
+
+// validateSpanRelations validates span event and link matchers
+func (l *Loader) validateSpanRelations(events []SpanEventMatcher, links []SpanLinkMatcher) error {
+	for i, event := range events {
+		if event.Name == "" && len(event.Attributes) == 0 && event.Count == nil {
+			return fmt.Errorf("event %d: at least one field must be specified", i)
+		}
+		if err := l.validateCountMatcher(event.Count); err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
+		}
+		if err := l.validateAttributeExpressions("attributes", event.Attributes); err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
+		}
+	}
+	for i, link := range links {
+		if link.Span == "" && link.TraceID == "" && link.SpanID == "" &&
+			len(link.Attributes) == 0 && link.Count == nil {
+			return fmt.Errorf("link %d: at least one field must be specified", i)
+		}
+		if link.TraceID != "" && !isHexID(link.TraceID, 16) {
+			return fmt.Errorf("link %d: trace_id must be 32 hex characters", i)
+		}
+		if link.SpanID != "" && !isHexID(link.SpanID, 8) {
+			return fmt.Errorf("link %d: span_id must be 16 hex characters", i)
+		}
+		if err := l.validateCountMatcher(link.Count); err != nil {
+			return fmt.Errorf("link %d: %w", i, err)
+		}
+		if err := l.validateAttributeExpressions("attributes", link.Attributes); err != nil {
+			return fmt.Errorf("link %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateLinkReferences checks that span links in inputs and matchers name input spans
+func (l *Loader) validateLinkReferences(contract *Contract) error {
+	names := make(map[string]bool)
+	for _, trace := range contract.Inputs.Traces {
+		names[trace.SpanName] = true
+	}
+	for i, trace := range contract.Inputs.Traces {
+		for j, link := range trace.Links {
+			if link.Span != "" && !names[link.Span] {
+				return fmt.Errorf("trace input %d: link %d: unknown input span %s", i, j, link.Span)
+			}
+		}
+	}
+	for i, matcher := range contract.Matchers.Traces {
+		for j, link := range matcher.Links {
+			if link.Span != "" && !names[link.Span] {
+				return fmt.Errorf("trace matcher %d: link %d: unknown input span %s", i, j, link.Span)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCorrelationReferences checks that log inputs, exemplar inputs and
+// correlations name input spans
+func (l *Loader) validateCorrelationReferences(contract *Contract) error {
+	names := make(map[string]bool)
+	for _, trace := range contract.Inputs.Traces {
+		names[trace.SpanName] = true
+	}
+	for i, log := range contract.Inputs.Logs {
+		if log.Span != "" && !names[log.Span] {
+			return fmt.Errorf("log input %d: unknown input span %s", i, log.Span)
+		}
+	}
+	for i, metric := range contract.Inputs.Metrics {
+		for j, exemplar := range metric.Exemplars {
+			if exemplar.Span != "" && !names[exemplar.Span] {
+				return fmt.Errorf("metric input %d: exemplar %d: unknown input span %s", i, j, exemplar.Span)
+			}
+		}
+	}
+	for i, matcher := range contract.Matchers.Correlations {
+		if matcher.Span != "" && !names[matcher.Span] {
+			return fmt.Errorf("correlation %d: unknown input span %s", i, matcher.Span)
+		}
+	}
+	return nil
+}
+
+// isHexID reports whether an ID is a hex string of the given byte length
+func isHexID(id string, size int) bool {
+	if len(id) != size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+// validateExpectations validates the per-signal expected outcomes
+func (l *Loader) validateExpectations(expect *Expectations) error {
+	if expect.Traces == "" && expect.Metrics == "" && expect.Logs == "" {
+		return fmt.Errorf("at least one signal (traces, metrics, or logs) must be specified")
+	}
+	if err := l.validateExpectMode(expect.Traces); err != nil {
+		return fmt.Errorf("traces: %w", err)
+	}
+	if err := l.validateExpectMode(expect.Metrics); err != nil {
+		return fmt.Errorf("metrics: %w", err)
+	}
+	if err := l.validateExpectMode(expect.Logs); err != nil {
+		return fmt.Errorf("logs: %w", err)
+	}
+	return nil
+}
+
+// validateGolden validates a golden snapshot reference and its mask rules
+func (l *Loader) validateGolden(golden *GoldenMatcher) error {
+	if golden.File == "" {
+		return fmt.Errorf("file is required")
+	}
+	for i, key := range golden.Mask.Attributes {
+		if key == "" {
+			return fmt.Errorf("mask attribute %d: key must not be empty", i)
+		}
+	}
+	for i, field := range golden.Mask.Fields {
+		if field == "" {
+			return fmt.Errorf("mask field %d: name must not be empty", i)
+		}
+	}
+	return nil
+}
+
+// validateSemconv validates a semantic convention check against the bundled versions
+func (l *Loader) validateSemconv(matcher *SemconvMatcher) error {
+	if matcher.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	if _, err := semconv.Load(matcher.Version); err != nil {
+		return err
+	}
+	switch matcher.Level {
+	case "", SemconvLevelRequired, SemconvLevelRecommended:
+		return nil
+	default:
+		return fmt.Errorf("invalid level %q (must be required or recommended)", matcher.Level)
+	}
+}
+
+// validateLogBody validates the body pattern and body field paths of a log matcher
+func (l *Loader) validateLogBody(matcher LogMatcher) error {
+	if matcher.BodyMatches != "" {
+		if _, err := regexp.Compile(matcher.BodyMatches); err != nil {
+			return fmt.Errorf("body_matches: invalid pattern %q: %w", matcher.BodyMatches, err)
+		}
+	}
+	for key := range matcher.BodyFields {
+		if _, err := ParseFieldPath(strings.TrimPrefix(key, "!")); err != nil {
+			return fmt.Errorf("body_fields: %w", err)
+		}
+	}
+	if matcher.ParseJSON && len(matcher.BodyFields) == 0 {
+		return fmt.Errorf("parse_json requires body_fields")
+	}
+	return l.validateAttributeExpressions("body_fields", matcher.BodyFields)
+}
+
+// validateCorrelation validates a cross-signal correlation
+func (l *Loader) validateCorrelation(matcher CorrelationMatcher) error {
+	switch matcher.Source {
+	case CorrelationSourceLogs:
+		if matcher.Metric != "" {
+			return fmt.Errorf("metric only applies to exemplars")
+		}
+	case CorrelationSourceExemplars:
+		if matcher.Body != "" {
+			return fmt.Errorf("body only applies to logs")
+		}
+	case "":
+		return fmt.Errorf("source is required")
+	default:
+		return fmt.Errorf("invalid source %q (must be logs or exemplars)", matcher.Source)
+	}
+	return nil
+}
+
+// validateCardinality validates a metric cardinality bound
+func (l *Loader) validateCardinality(matcher CardinalityMatcher) error {
+	if matcher.Metric == "" {
+		return fmt.Errorf("metric is required")
+	}
+	if matcher.Max == nil && len(matcher.ForbiddenDimensions) == 0 {
+		return fmt.Errorf("max or forbidden_dimensions must be specified")
+	}
+	if matcher.Max != nil && *matcher.Max < 0 {
+		return fmt.Errorf("max must not be negative")
+	}
+	for _, dimension := range matcher.ForbiddenDimensions {
+		if dimension == "" {
+			return fmt.Errorf("forbidden_dimensions must not contain an empty attribute name")
+		}
+	}
+	return nil
+}
+
+// validateBatches validates a batch matcher
+func (l *Loader) validateBatches(matcher *BatchMatcher) error {
+	switch matcher.Signal {
+	case "", SignalTypeTraces, SignalTypeMetrics, SignalTypeLogs:
+	default:
+		return fmt.Errorf("invalid signal %q (must be traces, metrics, or logs)", matcher.Signal)
+	}
+	if matcher.Count == nil {
+		return fmt.Errorf("count is required")
+	}
+	return l.validateCountMatcher(matcher.Count)
+}
+
+// validateExpectMode validates an expected outcome; an empty mode is allowed
+func (l *Loader) validateExpectMode(mode ExpectMode) error {
+	switch mode {
+	case "", ExpectDropped, ExpectPassedThrough, ExpectUnchanged:
+		return nil
+	default:
+		return fmt.Errorf("invalid expect %q (must be dropped, passed_through, or unchanged)", mode)
+	}
+}
+
+// validateQuantifier validates a matcher quantifier
+func (l *Loader) validateQuantifier(quantifier Quantifier) error {
+	switch quantifier.Mode {
+	case "", QuantifierAny, QuantifierAll, QuantifierNone:
+		return nil
+	case QuantifierExactly:
+		if quantifier.Count < 0 {
+			return fmt.Errorf("quantifier exactly must not be negative")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid quantifier %s", quantifier.Mode)
+	}
+}
+
+// validateCountMatcher validates a count matcher
+func (l *Loader) validateCountMatcher(count *CountMatcher) error {
+	if count == nil {
+		return nil
+	}
+	if count.Min != nil && count.Max != nil && *count.Min > *count.Max {
+		return fmt.Errorf("count min %d is greater than max %d", *count.Min, *count.Max)
+	}
+	switch count.Operator {
+	case "", FilterOperatorEquals, FilterOperatorGreaterThan, FilterOperatorLessThan,
+		FilterOperatorGreaterOrEqual, FilterOperatorLessOrEqual:
+		return nil
+	default:
+		return fmt.Errorf("count: invalid operator %s", count.Operator)
+	}
+}
+
+// validateDurationMatcher validates a duration matcher
+func (l *Loader) validateDurationMatcher(duration *DurationMatcher) error {
+	if duration == nil {
+		return nil
+	}
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"min", duration.Min},
+		{"max", duration.Max},
+		{"expected", duration.Expected},
+		{"tolerance", duration.Tolerance},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(field.value); err != nil {
+			return fmt.Errorf("duration %s: %w", field.name, err)
+		}
+	}
+	return nil
+}
+
+// validateStatusCodeMatcher validates a status code matcher
+func (l *Loader) validateStatusCodeMatcher(statusCode *StatusCodeMatcher) error {
+	if statusCode == nil || statusCode.Class == "" {
+		return nil
+	}
+	class := strings.ToLower(statusCode.Class)
+	if len(class) != 3 || !strings.HasSuffix(class, "xx") || class[0] < '1' || class[0] > '5' {
+		return fmt.Errorf("status_code: invalid class %s (expected 1xx-5xx)", statusCode.Class)
+	}
+	return nil
+}
+
+// validateTimestampMatcher validates a timestamp matcher
+func (l *Loader) validateTimestampMatcher(timestamp *TimestampMatcher) error {
+	if timestamp == nil {
+		return nil
+	}
+	switch strings.ToLower(timestamp.Format) {
+	case "", "rfc3339", "unix", "unix_milli", "unix_nano":
+	default:
+		return fmt.Errorf("timestamp: invalid format %s", timestamp.Format)
+	}
+	switch strings.ToLower(timestamp.Precision) {
+	case "", "second", "millisecond", "microsecond", "nanosecond":
+	default:
+		return fmt.Errorf("timestamp: invalid precision %s", timestamp.Precision)
+	}
+	if timestamp.Relative != "" && !strings.HasPrefix(timestamp.Relative, "within_last_") {
+		return fmt.Errorf("timestamp: invalid relative window %s", timestamp.Relative)
+	}
 	return nil
 }
 
@@ -5,7 +5,10 @@ package contract
 
 import (
 	"os"
+	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestLoader_LoadFromPaths(t *testing.T) {
@@ -117,3 +120,507 @@ func TestLoader_ValidateContract(t *testing.T) {
 		t.Error("Expected error for invalid contract, got none")
 	}
 }
+
+func TestLoader_Quantifier(t *testing.T) {
+	testContract := `
+publisher: "test-service"
+pipeline: "test-pipeline"
+version: "1.0"
+inputs:
+  traces:
+    - span_name: "test_operation"
+matchers:
+  traces:
+    - span_name: "test_operation"
+      quantifier: all
+    - span_name: "debug_operation"
+      quantifier: none
+    - span_name: "test_operation"
+      quantifier:
+        exactly: 2
+`
+
+	tmpFile, err := os.CreateTemp("", "test_contract_*.yaml")
+	if err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+	defer func() {
+		if err := os.Remove(tmpFile.Name()); err != nil {
+			t.Logf("Failed to remove temp file: %v", err)
+		}
+	}()
+
+	if _, err := tmpFile.WriteString(testContract); err != nil {
+		t.Fatalf("Failed to write test contract: %v", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		t.Fatalf("Failed to close temp file: %v", err)
+	}
+
+	loader := NewLoader()
+	contracts, errors := loader.LoadFromPaths([]string{tmpFile.Name()})
+	if len(errors) > 0 {
+		t.Fatalf("Expected no errors, got: %v", errors)
+	}
+
+	matchers := contracts[0].Matchers.Traces
+	if matchers[0].Quantifier.GetMode() != QuantifierAll {
+		t.Errorf("Expected quantifier all, got %s", matchers[0].Quantifier)
+	}
+	if matchers[1].Quantifier.GetMode() != QuantifierNone {
+		t.Errorf("Expected quantifier none, got %s", matchers[1].Quantifier)
+	}
+	if matchers[2].Quantifier.GetMode() != QuantifierExactly || matchers[2].Quantifier.Count != 2 {
+		t.Errorf("Expected quantifier exactly 2, got %s", matchers[2].Quantifier)
+	}
+
+	// Matchers without a quantifier default to any
+	if (Quantifier{}).GetMode() != QuantifierAny {
+		t.Errorf("Expected default quantifier any")
+	}
+
+	// Unknown quantifiers are rejected
+	invalid := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "test_operation"}}},
+		Matchers: Matchers{Traces: []TraceMatcher{{
+			SpanName:   "test_operation",
+			Quantifier: Quantifier{Mode: "most"},
+		}}},
+	}
+	if err := loader.validateContract(invalid); err == nil {
+		t.Error("Expected error for invalid quantifier, got none")
+	}
+}
+
+func TestLoader_SubMatcherOnlyMatchers(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "test_operation"}}},
+		Matchers: Matchers{
+			Traces:  []TraceMatcher{{Duration: &DurationMatcher{Max: "1s"}}},
+			Metrics: []MetricMatcher{{Value: &ValueMatcher{Expected: 1}}},
+			Logs:    []LogMatcher{{Timestamp: &TimestampMatcher{Relative: "within_last_hour"}}},
+		},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected matchers with only sub-matchers to be valid, got: %v", err)
+	}
+
+	contract.Matchers.Traces[0].Duration.Max = "soon"
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for invalid duration, got none")
+	}
+
+	contract.Matchers.Traces[0] = TraceMatcher{StatusCode: &StatusCodeMatcher{Class: "6xx"}}
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for invalid status code class, got none")
+	}
+}
+
+func TestLoader_AttributeExpressions(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "test_operation"}}},
+		Matchers: Matchers{
+			Traces: []TraceMatcher{{Attributes: map[string]interface{}{
+				"http.route":  map[string]interface{}{"matches": "^/api/v\\d+"},
+				"http.method": map[string]interface{}{"one_of": []interface{}{"GET", "POST"}},
+				"retry.count": map[string]interface{}{"gt": 0, "type": "int"},
+			}}},
+		},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected attribute expressions to be valid, got: %v", err)
+	}
+
+	invalid := []interface{}{
+		map[string]interface{}{"matches": "("},
+		map[string]interface{}{"one_of": "GET"},
+		map[string]interface{}{"exists": "yes"},
+		map[string]interface{}{"type": "uuid"},
+	}
+	for _, expression := range invalid {
+		contract.Matchers.Traces[0].Attributes = map[string]interface{}{"key": expression}
+		if err := loader.validateContract(contract); err == nil {
+			t.Errorf("Expected error for expression %v, got none", expression)
+		}
+	}
+}
+
+func TestLoader_ResourceAndScopeMatchers(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "test_operation"}}},
+		Matchers: Matchers{
+			Traces: []TraceMatcher{{Resource: &ResourceMatcher{
+				Attributes: map[string]interface{}{"deployment.environment": "production"},
+			}}},
+			Logs: []LogMatcher{{Scope: &ScopeMatcher{Name: "io.opentelemetry.http"}}},
+		},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected resource and scope matchers to be valid, got: %v", err)
+	}
+
+	contract.Matchers.Logs[0].Scope = &ScopeMatcher{}
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for empty scope matcher, got none")
+	}
+}
+
+func TestLoader_SpanEventsAndLinks(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs: Inputs{Traces: []TraceInput{
+			{SpanName: "enqueue"},
+			{SpanName: "process", Links: []SpanLinkInput{{Span: "enqueue"}}},
+		}},
+		Matchers: Matchers{
+			Traces: []TraceMatcher{{
+				Events: []SpanEventMatcher{{Name: "exception"}},
+				Links:  []SpanLinkMatcher{{Span: "enqueue", TraceID: "0102030405060708090a0b0c0d0e0f10"}},
+			}},
+		},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected span events and links to be valid, got: %v", err)
+	}
+
+	contract.Matchers.Traces[0].Links[0].Span = "dequeue"
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for link to unknown input span, got none")
+	}
+
+	contract.Matchers.Traces[0].Links[0] = SpanLinkMatcher{SpanID: "xyz"}
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for invalid span ID, got none")
+	}
+}
+
+func TestLoader_TraceStructure(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "GET /cart"}}},
+		Matchers: Matchers{
+			TraceStructure: &TraceStructureMatcher{
+				RootSpan: "GET /cart",
+				Edges:    []SpanEdge{{Parent: "GET /cart", Child: "load cart"}},
+			},
+		},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected trace structure alone to be a valid matcher, got: %v", err)
+	}
+
+	contract.Matchers.TraceStructure.Edges[0].Child = ""
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for incomplete edge, got none")
+	}
+}
+
+func TestLoader_Expectations(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs: Inputs{Traces: []TraceInput{
+			{SpanName: "GET /health", Expect: ExpectDropped},
+			{SpanName: "GET /cart"},
+		}},
+		Matchers: Matchers{Expect: &Expectations{Traces: ExpectPassedThrough}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected expectations alone to be a valid matcher, got: %v", err)
+	}
+
+	modes := contract.InputExpectations(SignalTypeTraces)
+	if len(modes) != 2 || modes[0] != ExpectDropped || modes[1] != ExpectPassedThrough {
+		t.Errorf("Expected input expect to override the signal default, got %v", modes)
+	}
+
+	contract.Matchers.Expect = nil
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected a per-input expect to be a valid matcher, got: %v", err)
+	}
+
+	contract.Inputs.Traces[0].Expect = "filtered"
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for invalid expect mode, got none")
+	}
+}
+
+func TestLoader_Golden(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "GET /cart"}}},
+		Matchers: Matchers{Golden: &GoldenMatcher{
+			File: "golden/cart.json",
+			Mask: GoldenMask{Attributes: []string{"request.id"}},
+		}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected golden snapshot alone to be a valid matcher, got: %v", err)
+	}
+	if !contract.Matchers.Golden.Mask.MaskIDs() || !contract.Matchers.Golden.Mask.MaskTimestamps() {
+		t.Error("Expected IDs and timestamps to be masked by default")
+	}
+
+	contract.Matchers.Golden.File = ""
+	if err := loader.validateContract(contract); err == nil {
+		t.Error("Expected error for golden snapshot without file, got none")
+	}
+}
+
+func TestLoader_Semconv(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "GET /cart"}}},
+		Matchers:  Matchers{Semconv: &SemconvMatcher{Version: "1.26.0"}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected semconv alone to be a valid matcher, got: %v", err)
+	}
+	if level := contract.Matchers.Semconv.GetLevel(); level != SemconvLevelRequired {
+		t.Errorf("Expected default level required, got %s", level)
+	}
+
+	contract.Matchers.Semconv.Level = "optional"
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), `invalid level "optional"`) {
+		t.Errorf("Expected invalid level error, got: %v", err)
+	}
+
+	contract.Matchers.Semconv = &SemconvMatcher{Version: "0.1.0"}
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "available: 1.26.0") {
+		t.Errorf("Expected unsupported version error listing bundled versions, got: %v", err)
+	}
+}
+
+func TestLoader_Cardinality(t *testing.T) {
+	loader := NewLoader()
+
+	max := 10
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Metrics: []MetricInput{{Name: "http.server.duration", Value: 1.0}}},
+		Matchers: Matchers{Cardinality: []CardinalityMatcher{
+			{Metric: "http.server.duration", Max: &max, ForbiddenDimensions: []string{"user.id"}},
+		}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected cardinality alone to be a valid matcher, got: %v", err)
+	}
+
+	contract.Matchers.Cardinality = []CardinalityMatcher{{Metric: "http.server.duration"}}
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "max or forbidden_dimensions must be specified") {
+		t.Errorf("Expected missing bound error, got: %v", err)
+	}
+
+	negative := -1
+	contract.Matchers.Cardinality = []CardinalityMatcher{{Metric: "http.server.duration", Max: &negative}}
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "max must not be negative") {
+		t.Errorf("Expected negative max error, got: %v", err)
+	}
+
+	contract.Matchers.Cardinality = []CardinalityMatcher{{Max: &max}}
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "metric is required") {
+		t.Errorf("Expected missing metric error, got: %v", err)
+	}
+}
+
+func TestLoader_Batches(t *testing.T) {
+	loader := NewLoader()
+
+	var matchers Matchers
+	if err := yaml.Unmarshal([]byte("batches: {count: 3}"), &matchers); err != nil {
+		t.Fatalf("Failed to parse scalar batch count: %v", err)
+	}
+	if matchers.Batches == nil || matchers.Batches.Count == nil || matchers.Batches.Count.Expected != 3 {
+		t.Fatalf("Expected batches count 3, got %+v", matchers.Batches)
+	}
+	if err := yaml.Unmarshal([]byte("batches: {signal: logs, count: {min: 2}}"), &matchers); err != nil {
+		t.Fatalf("Failed to parse batch count mapping: %v", err)
+	}
+	if matchers.Batches.Count.Min == nil || *matchers.Batches.Count.Min != 2 || matchers.Batches.Signal != SignalTypeLogs {
+		t.Errorf("Expected logs batches count min 2, got %+v", matchers.Batches)
+	}
+	if err := yaml.Unmarshal([]byte("batches: {count: many}"), &matchers); err == nil {
+		t.Error("Expected a non-integer batch count to be rejected")
+	}
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Logs: []LogInput{{Body: "started"}}},
+		Matchers:  Matchers{Batches: &BatchMatcher{Count: &CountMatcher{Expected: 3}}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected batches alone to be a valid matcher, got: %v", err)
+	}
+
+	contract.Matchers.Batches.Signal = "spans"
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), `invalid signal "spans"`) {
+		t.Errorf("Expected invalid signal error, got: %v", err)
+	}
+
+	contract.Matchers.Batches = &BatchMatcher{Signal: SignalTypeLogs}
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "count is required") {
+		t.Errorf("Expected missing count error, got: %v", err)
+	}
+}
+
+func TestLoader_Correlations(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs: Inputs{
+			Traces:  []TraceInput{{SpanName: "POST /pay"}},
+			Metrics: []MetricInput{{Name: "http.server.duration", Value: 0.2, Exemplars: []ExemplarInput{{Span: "POST /pay"}}}},
+			Logs:    []LogInput{{Body: "payment failed", Span: "POST /pay"}},
+		},
+		Matchers: Matchers{Correlations: []CorrelationMatcher{
+			{Source: CorrelationSourceLogs, Body: "payment failed", Span: "POST /pay"},
+			{Source: CorrelationSourceExemplars, Metric: "http.server.duration"},
+		}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected correlations alone to be valid matchers, got: %v", err)
+	}
+
+	contract.Matchers.Correlations[1].Source = "spans"
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), `invalid source "spans"`) {
+		t.Errorf("Expected invalid source error, got: %v", err)
+	}
+
+	contract.Matchers.Correlations[1] = CorrelationMatcher{Source: CorrelationSourceExemplars, Body: "payment failed"}
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "body only applies to logs") {
+		t.Errorf("Expected body on exemplars to be rejected, got: %v", err)
+	}
+
+	contract.Matchers.Correlations = contract.Matchers.Correlations[:1]
+	contract.Inputs.Logs[0].Span = "GET /cart"
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "log input 0: unknown input span GET /cart") {
+		t.Errorf("Expected unknown input span error, got: %v", err)
+	}
+}
+
+func TestLoader_LogBodyAndSeverity(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    Inputs{Logs: []LogInput{{Body: `{"user": {"id": "u-1"}}`}}},
+		Matchers: Matchers{Logs: []LogMatcher{{
+			ParseJSON:  true,
+			BodyFields: map[string]interface{}{"user.id": "u-1"},
+			Severity:   SeverityMatcher{Min: "WARN"},
+		}}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected body fields and severity range to be valid, got: %v", err)
+	}
+
+	tests := []struct {
+		matcher LogMatcher
+		want    string
+	}{
+		{LogMatcher{BodyMatches: "("}, "body_matches: invalid pattern"},
+		{LogMatcher{BodyFields: map[string]interface{}{"items[": "a"}}, "body_fields: invalid field path"},
+		{LogMatcher{ParseJSON: true, Body: "x"}, "parse_json requires body_fields"},
+		{LogMatcher{Severity: SeverityMatcher{Min: "LOUD"}}, `severity: min: unknown severity "LOUD"`},
+	}
+	for _, tt := range tests {
+		contract.Matchers.Logs = []LogMatcher{tt.matcher}
+		if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+		}
+	}
+}
+
+func TestLoader_Timeout(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Timeout:   "250ms",
+		Inputs:    Inputs{Traces: []TraceInput{{SpanName: "op"}}},
+		Matchers:  Matchers{Traces: []TraceMatcher{{SpanName: "op"}}},
+	}
+	if err := loader.validateContract(contract); err != nil {
+		t.Errorf("Expected timeout to be valid, got: %v", err)
+	}
+
+	for _, timeout := range []string{"soon", "-1s", "0s"} {
+		contract.Timeout = timeout
+		if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), "timeout") {
+			t.Errorf("Expected timeout %q to be rejected, got: %v", timeout, err)
+		}
+	}
+}
+
+func TestLoader_PipelineMatchPolicy(t *testing.T) {
+	loader := NewLoader()
+
+	contract := &Contract{
+		Publisher: "test-service",
+		Version:   "1.0",
+		PipelineSelectors: &PipelineSelectors{
+			Selectors: []PipelineSelector{{Field: "type", Operator: PipelineSelectorOperatorEquals, Value: "trace"}},
+		},
+		Inputs:   Inputs{Traces: []TraceInput{{SpanName: "op"}}},
+		Matchers: Matchers{Traces: []TraceMatcher{{SpanName: "op"}}},
+	}
+	for _, policy := range []PipelineMatchPolicy{"", PipelineMatchAll, PipelineMatchBest, PipelineMatchExactlyOne} {
+		contract.PipelineSelectors.Match = policy
+		if err := loader.validateContract(contract); err != nil {
+			t.Errorf("Expected match policy %q to be valid, got: %v", policy, err)
+		}
+	}
+
+	contract.PipelineSelectors.Match = "first"
+	if err := loader.validateContract(contract); err == nil || !strings.Contains(err.Error(), `invalid match policy "first"`) {
+		t.Errorf("Expected invalid match policy error, got: %v", err)
+	}
+}
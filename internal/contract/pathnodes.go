@@ -0,0 +1,444 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// telemetryRoot exposes the spans, metrics, logs and resources of output data to field paths
+type telemetryRoot struct {
+	data OpenTelemetryData
+}
+
+func (r telemetryRoot) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "spans", "span":
+		return r.spans(), true
+	case "metrics", "metric":
+		return r.metrics(), true
+	case "logs", "log":
+		return r.logs(), true
+	case "resource", "resources":
+		return r.resources(), true
+	}
+	return nil, false
+}
+
+func (r telemetryRoot) spans() pathCollection {
+	var spans pathCollection
+	for i := 0; i < r.data.Traces.ResourceSpans().Len(); i++ {
+		resourceSpans := r.data.Traces.ResourceSpans().At(i)
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			scopeSpans := resourceSpans.ScopeSpans().At(j)
+			for k := 0; k < scopeSpans.Spans().Len(); k++ {
+				spans = append(spans, spanNode{
+					span:     scopeSpans.Spans().At(k),
+					resource: resourceSpans.Resource(),
+					scope:    scopeSpans.Scope(),
+				})
+			}
+		}
+	}
+	return spans
+}
+
+func (r telemetryRoot) metrics() pathCollection {
+	var metrics pathCollection
+	for i := 0; i < r.data.Metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := r.data.Metrics.ResourceMetrics().At(i)
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			scopeMetrics := resourceMetrics.ScopeMetrics().At(j)
+			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
+				metrics = append(metrics, metricNode{
+					metric:   scopeMetrics.Metrics().At(k),
+					resource: resourceMetrics.Resource(),
+					scope:    scopeMetrics.Scope(),
+				})
+			}
+		}
+	}
+	return metrics
+}
+
+func (r telemetryRoot) logs() pathCollection {
+	var logs pathCollection
+	for i := 0; i < r.data.Logs.ResourceLogs().Len(); i++ {
+		resourceLogs := r.data.Logs.ResourceLogs().At(i)
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				logs = append(logs, logNode{
+					record:   scopeLogs.LogRecords().At(k),
+					resource: resourceLogs.Resource(),
+					scope:    scopeLogs.Scope(),
+				})
+			}
+		}
+	}
+	return logs
+}
+
+// resources returns the resource of every resource span, metric and log group
+func (r telemetryRoot) resources() pathCollection {
+	var resources pathCollection
+	for i := 0; i < r.data.Traces.ResourceSpans().Len(); i++ {
+		resources = append(resources, resourceNode{r.data.Traces.ResourceSpans().At(i).Resource()})
+	}
+	for i := 0; i < r.data.Metrics.ResourceMetrics().Len(); i++ {
+		resources = append(resources, resourceNode{r.data.Metrics.ResourceMetrics().At(i).Resource()})
+	}
+	for i := 0; i < r.data.Logs.ResourceLogs().Len(); i++ {
+		resources = append(resources, resourceNode{r.data.Logs.ResourceLogs().At(i).Resource()})
+	}
+	return resources
+}
+
+// resourceNode exposes a resource; as a leaf it yields its attributes
+type resourceNode struct {
+	resource pcommon.Resource
+}
+
+func (n resourceNode) pathField(name string) (interface{}, bool) {
+	if name == "attributes" {
+		return n.resource.Attributes(), true
+	}
+	return nil, false
+}
+
+func (n resourceNode) pathValue() interface{} {
+	return n.resource.Attributes().AsRaw()
+}
+
+// scopeNode exposes an instrumentation scope; as a leaf it yields its name
+type scopeNode struct {
+	scope pcommon.InstrumentationScope
+}
+
+func (n scopeNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "name":
+		return n.scope.Name(), true
+	case "version":
+		return n.scope.Version(), true
+	case "attributes":
+		return n.scope.Attributes(), true
+	}
+	return nil, false
+}
+
+func (n scopeNode) pathValue() interface{} {
+	return n.scope.Name()
+}
+
+// serviceNode exposes the service.* resource attributes; as a leaf it yields service.name
+type serviceNode struct {
+	attributes pcommon.Map
+}
+
+func (n serviceNode) pathField(name string) (interface{}, bool) {
+	value, ok := n.attributes.Get("service." + name)
+	if !ok {
+		return nil, false
+	}
+	return value, true
+}
+
+func (n serviceNode) pathValue() interface{} {
+	if value, ok := n.attributes.Get("service.name"); ok {
+		return ValueToInterface(value)
+	}
+	return nil
+}
+
+// spanNode exposes a span; as a leaf it yields its name
+type spanNode struct {
+	span     ptrace.Span
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+}
+
+func (n spanNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "name":
+		return n.span.Name(), true
+	case "kind":
+		return n.span.Kind().String(), true
+	case "trace_id":
+		return traceIDValue(n.span.TraceID()), true
+	case "span_id":
+		return spanIDValue(n.span.SpanID()), true
+	case "parent_span_id":
+		return spanIDValue(n.span.ParentSpanID()), true
+	case "service", "service_name":
+		return serviceNode{n.resource.Attributes()}, true
+	case "duration":
+		return n.span.EndTimestamp().AsTime().Sub(n.span.StartTimestamp().AsTime()).String(), true
+	case "start_time":
+		return n.span.StartTimestamp().AsTime(), true
+	case "end_time":
+		return n.span.EndTimestamp().AsTime(), true
+	case "status":
+		return statusNode{n.span.Status()}, true
+	case "attributes":
+		return n.span.Attributes(), true
+	case "resource":
+		return resourceNode{n.resource}, true
+	case "scope":
+		return scopeNode{n.scope}, true
+	case "events":
+		events := make(pathCollection, 0, n.span.Events().Len())
+		for i := 0; i < n.span.Events().Len(); i++ {
+			events = append(events, eventNode{n.span.Events().At(i)})
+		}
+		return events, true
+	case "links":
+		links := make(pathCollection, 0, n.span.Links().Len())
+		for i := 0; i < n.span.Links().Len(); i++ {
+			links = append(links, linkNode{n.span.Links().At(i)})
+		}
+		return links, true
+	}
+	return nil, false
+}
+
+func (n spanNode) pathValue() interface{} {
+	return n.span.Name()
+}
+
+// statusNode exposes a span status; as a leaf it yields the status code
+type statusNode struct {
+	status ptrace.Status
+}
+
+func (n statusNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "code":
+		return n.status.Code().String(), true
+	case "message":
+		return n.status.Message(), true
+	}
+	return nil, false
+}
+
+func (n statusNode) pathValue() interface{} {
+	return n.status.Code().String()
+}
+
+// eventNode exposes a span event; as a leaf it yields its name
+type eventNode struct {
+	event ptrace.SpanEvent
+}
+
+func (n eventNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "name":
+		return n.event.Name(), true
+	case "timestamp":
+		return n.event.Timestamp().AsTime(), true
+	case "attributes":
+		return n.event.Attributes(), true
+	}
+	return nil, false
+}
+
+func (n eventNode) pathValue() interface{} {
+	return n.event.Name()
+}
+
+// linkNode exposes a span link; as a leaf it yields the linked span ID
+type linkNode struct {
+	link ptrace.SpanLink
+}
+
+func (n linkNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "trace_id":
+		return traceIDValue(n.link.TraceID()), true
+	case "span_id":
+		return spanIDValue(n.link.SpanID()), true
+	case "attributes":
+		return n.link.Attributes(), true
+	}
+	return nil, false
+}
+
+func (n linkNode) pathValue() interface{} {
+	return spanIDValue(n.link.SpanID())
+}
+
+// metricNode exposes a metric; as a leaf it yields its name
+type metricNode struct {
+	metric   pmetric.Metric
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+}
+
+func (n metricNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "name":
+		return n.metric.Name(), true
+	case "type":
+		return MetricTypeName(n.metric), true
+	case "unit":
+		return n.metric.Unit(), true
+	case "description":
+		return n.metric.Description(), true
+	case "datapoints", "data_points", "value":
+		// A metric's value is the value of each of its data points
+		return n.dataPoints(), true
+	case "labels", "attributes":
+		var attributes pathCollection
+		for _, point := range n.dataPoints() {
+			attributes = append(attributes, point.(dataPointNode).attributes)
+		}
+		return attributes, true
+	case "resource":
+		return resourceNode{n.resource}, true
+	case "scope":
+		return scopeNode{n.scope}, true
+	}
+	return nil, false
+}
+
+func (n metricNode) pathValue() interface{} {
+	return n.metric.Name()
+}
+
+// dataPoints returns a node for every data point of the metric
+func (n metricNode) dataPoints() pathCollection {
+	var points pathCollection
+	switch n.metric.Type() {
+	case pmetric.MetricTypeGauge:
+		points = numberPoints(n.metric.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		points = numberPoints(n.metric.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < n.metric.Histogram().DataPoints().Len(); i++ {
+			dp := n.metric.Histogram().DataPoints().At(i)
+			fields := map[string]interface{}{"count": dp.Count(), "timestamp": dp.Timestamp().AsTime()}
+			if dp.HasSum() {
+				fields["sum"] = dp.Sum()
+			}
+			if dp.HasMin() {
+				fields["min"] = dp.Min()
+			}
+			if dp.HasMax() {
+				fields["max"] = dp.Max()
+			}
+			points = append(points, dataPointNode{attributes: dp.Attributes(), fields: fields, value: "count"})
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < n.metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			dp := n.metric.ExponentialHistogram().DataPoints().At(i)
+			fields := map[string]interface{}{"count": dp.Count(), "scale": int64(dp.Scale()), "timestamp": dp.Timestamp().AsTime()}
+			if dp.HasSum() {
+				fields["sum"] = dp.Sum()
+			}
+			points = append(points, dataPointNode{attributes: dp.Attributes(), fields: fields, value: "count"})
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < n.metric.Summary().DataPoints().Len(); i++ {
+			dp := n.metric.Summary().DataPoints().At(i)
+			fields := map[string]interface{}{"count": dp.Count(), "sum": dp.Sum(), "timestamp": dp.Timestamp().AsTime()}
+			points = append(points, dataPointNode{attributes: dp.Attributes(), fields: fields, value: "count"})
+		}
+	}
+	return points
+}
+
+// numberPoints returns nodes for gauge or sum data points
+func numberPoints(dataPoints pmetric.NumberDataPointSlice) pathCollection {
+	points := make(pathCollection, 0, dataPoints.Len())
+	for i := 0; i < dataPoints.Len(); i++ {
+		dp := dataPoints.At(i)
+		fields := map[string]interface{}{"timestamp": dp.Timestamp().AsTime()}
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			fields["value"] = dp.IntValue()
+		case pmetric.NumberDataPointValueTypeDouble:
+			fields["value"] = dp.DoubleValue()
+		}
+		points = append(points, dataPointNode{attributes: dp.Attributes(), fields: fields, value: "value"})
+	}
+	return points
+}
+
+// dataPointNode exposes a metric data point. As a leaf it yields its value, or its
+// count for histograms and summaries.
+type dataPointNode struct {
+	attributes pcommon.Map
+	fields     map[string]interface{}
+	value      string // The field the data point stands for as a leaf
+}
+
+func (n dataPointNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "attributes", "labels":
+		return n.attributes, true
+	case "value":
+		value, ok := n.fields[n.value]
+		return value, ok
+	}
+	value, ok := n.fields[name]
+	return value, ok
+}
+
+func (n dataPointNode) pathValue() interface{} {
+	return n.fields[n.value]
+}
+
+// logNode exposes a log record; as a leaf it yields its body
+type logNode struct {
+	record   plog.LogRecord
+	resource pcommon.Resource
+	scope    pcommon.InstrumentationScope
+}
+
+func (n logNode) pathField(name string) (interface{}, bool) {
+	switch name {
+	case "body":
+		return n.record.Body(), true
+	case "severity", "severity_text":
+		return n.record.SeverityText(), true
+	case "severity_number":
+		return int64(n.record.SeverityNumber()), true
+	case "timestamp":
+		return n.record.Timestamp().AsTime(), true
+	case "trace_id":
+		return traceIDValue(n.record.TraceID()), true
+	case "span_id":
+		return spanIDValue(n.record.SpanID()), true
+	case "attributes":
+		return n.record.Attributes(), true
+	case "resource":
+		return resourceNode{n.resource}, true
+	case "scope":
+		return scopeNode{n.scope}, true
+	}
+	return nil, false
+}
+
+func (n logNode) pathValue() interface{} {
+	return ValueToInterface(n.record.Body())
+}
+
+// traceIDValue returns a trace ID as hex, or nil when it is unset
+func traceIDValue(id pcommon.TraceID) interface{} {
+	if id.IsEmpty() {
+		return nil
+	}
+	return id.String()
+}
+
+// spanIDValue returns a span ID as hex, or nil when it is unset
+func spanIDValue(id pcommon.SpanID) interface{} {
+	if id.IsEmpty() {
+		return nil
+	}
+	return id.String()
+}
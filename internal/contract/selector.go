@@ -6,6 +6,7 @@ package contract
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -17,6 +18,49 @@ type PipelineInfo struct {
 	Type        string            `json:"type,omitempty"`
 	Tags        map[string]string `json:"tags,omitempty"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Priority    int               `json:"priority,omitempty"`    // Preference among pipelines matching the same contract
+	Specificity int               `json:"specificity,omitempty"` // How precisely the runner configuration's selectors describe the pipeline
+}
+
+// PipelineMatch is a pipeline that satisfies a contract's selectors, with the score it is ranked by
+type PipelineMatch struct {
+	Pipeline    *PipelineInfo
+	Priority    int // The pipeline's priority
+	Specificity int // How precisely the contract's selectors pin this pipeline down, plus the pipeline's specificity
+}
+
+// String describes the match and its score
+func (m PipelineMatch) String() string {
+	return fmt.Sprintf("%s (priority %d, specificity %d)", m.Pipeline.ID, m.Priority, m.Specificity)
+}
+
+// AmbiguousPipelineError reports a selection that could not settle on one pipeline
+type AmbiguousPipelineError struct {
+	Policy     PipelineMatchPolicy
+	Candidates []PipelineMatch
+}
+
+// Error implements error
+func (e *AmbiguousPipelineError) Error() string {
+	candidates := make([]string, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		candidates[i] = candidate.String()
+	}
+	if e.Policy == PipelineMatchExactlyOne {
+		return fmt.Sprintf("expected exactly one matching pipeline, found %d: %s",
+			len(e.Candidates), strings.Join(candidates, ", "))
+	}
+	return fmt.Sprintf("ambiguous pipeline selection: %d pipelines tie for the best match: %s",
+		len(e.Candidates), strings.Join(candidates, ", "))
+}
+
+// selectorSpecificity weighs operators by how narrowly they constrain a field
+var selectorSpecificity = map[PipelineSelectorOperator]int{
+	PipelineSelectorOperatorEquals:     4,
+	PipelineSelectorOperatorStartsWith: 3,
+	PipelineSelectorOperatorEndsWith:   3,
+	PipelineSelectorOperatorContains:   2,
+	PipelineSelectorOperatorMatches:    1,
 }
 
 // PipelineSelectorService handles matching contracts to pipelines based on selectors
@@ -43,7 +87,7 @@ func (s *PipelineSelectorService) RegisterPipelines(pipelines []*PipelineInfo) {
 	}
 }
 
-// FindMatchingPipelines finds all pipelines that match the given selectors
+// FindMatchingPipelines finds all pipelines that match the given selectors, sorted by ID
 func (s *PipelineSelectorService) FindMatchingPipelines(selectors *PipelineSelectors) ([]*PipelineInfo, error) {
 	if selectors == nil || len(selectors.Selectors) == 0 {
 		return nil, fmt.Errorf("no selectors provided")
@@ -51,7 +95,7 @@ func (s *PipelineSelectorService) FindMatchingPipelines(selectors *PipelineSelec
 
 	var matchingPipelines []*PipelineInfo
 
-	for _, pipeline := range s.pipelines {
+	for _, pipeline := range s.ListPipelines() {
 		if s.matchesSelectors(pipeline, selectors.Selectors) {
 			matchingPipelines = append(matchingPipelines, pipeline)
 		}
@@ -60,24 +104,130 @@ func (s *PipelineSelectorService) FindMatchingPipelines(selectors *PipelineSelec
 	return matchingPipelines, nil
 }
 
-// FindBestMatchingPipeline finds the best matching pipeline for the given selectors
-func (s *PipelineSelectorService) FindBestMatchingPipeline(selectors *PipelineSelectors) (*PipelineInfo, error) {
-	matches, err := s.FindMatchingPipelines(selectors)
+// RankPipelines scores every pipeline that matches the given selectors, best first.
+// A positive selector priority is the minimum priority a pipeline needs to be ranked.
+// Matches are ordered by priority, then specificity, then ID, so the ranking is deterministic.
+func (s *PipelineSelectorService) RankPipelines(selectors *PipelineSelectors) ([]PipelineMatch, error) {
+	pipelines, err := s.FindMatchingPipelines(selectors)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(matches) == 0 {
+	matches := make([]PipelineMatch, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		if selectors.Priority > 0 && pipeline.Priority < selectors.Priority {
+			continue
+		}
+		match := PipelineMatch{
+			Pipeline:    pipeline,
+			Priority:    pipeline.Priority,
+			Specificity: pipeline.Specificity,
+		}
+		for _, selector := range selectors.Selectors {
+			match.Specificity += SelectorSpecificity(pipeline, selector)
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Priority != matches[j].Priority {
+			return matches[i].Priority > matches[j].Priority
+		}
+		if matches[i].Specificity != matches[j].Specificity {
+			return matches[i].Specificity > matches[j].Specificity
+		}
+		return matches[i].Pipeline.ID < matches[j].Pipeline.ID
+	})
+	return matches, nil
+}
+
+// SelectPipelines returns the pipelines a contract runs against under its match policy,
+// or none when no pipeline matches. An ambiguous selection returns an AmbiguousPipelineError
+// listing the candidates.
+func (s *PipelineSelectorService) SelectPipelines(selectors *PipelineSelectors) ([]*PipelineInfo, error) {
+	matches, err := s.RankPipelines(selectors)
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+
+	switch selectors.Match {
+	case "", PipelineMatchAll:
+		pipelines := make([]*PipelineInfo, len(matches))
+		for i, match := range matches {
+			pipelines[i] = match.Pipeline
+		}
+		return pipelines, nil
+	case PipelineMatchBest:
+		tied := 1
+		for tied < len(matches) && matches[tied].Priority == matches[0].Priority &&
+			matches[tied].Specificity == matches[0].Specificity {
+			tied++
+		}
+		if tied > 1 {
+			return nil, &AmbiguousPipelineError{Policy: PipelineMatchBest, Candidates: matches[:tied]}
+		}
+		return []*PipelineInfo{matches[0].Pipeline}, nil
+	case PipelineMatchExactlyOne:
+		if len(matches) > 1 {
+			return nil, &AmbiguousPipelineError{Policy: PipelineMatchExactlyOne, Candidates: matches}
+		}
+		return []*PipelineInfo{matches[0].Pipeline}, nil
+	default:
+		return nil, fmt.Errorf("invalid match policy %q (must be best, all, or exactly_one)", selectors.Match)
+	}
+}
+
+// FindBestMatchingPipeline finds the highest scoring pipeline for the given selectors,
+// returning an AmbiguousPipelineError when several pipelines tie for the best score
+func (s *PipelineSelectorService) FindBestMatchingPipeline(selectors *PipelineSelectors) (*PipelineInfo, error) {
+	if selectors == nil {
+		return nil, fmt.Errorf("no selectors provided")
+	}
+
+	best := *selectors
+	best.Match = PipelineMatchBest
+	pipelines, err := s.SelectPipelines(&best)
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
 		return nil, fmt.Errorf("no matching pipelines found")
 	}
+	return pipelines[0], nil
+}
+
+// MatchesPipeline reports whether a pipeline satisfies a single selector
+func MatchesPipeline(pipeline *PipelineInfo, selector PipelineSelector) bool {
+	return (&PipelineSelectorService{}).matchesSelector(pipeline, selector)
+}
 
-	if len(matches) == 1 {
-		return matches[0], nil
+// SelectorSpecificity scores how precisely a pipeline satisfies a selector, or 0 when it
+// does not. A value the selector names exactly scores as equals whatever the operator,
+// otherwise the operator's weight applies, less 1 when the field path has a wildcard.
+func SelectorSpecificity(pipeline *PipelineInfo, selector PipelineSelector) int {
+	s := &PipelineSelectorService{}
+	path, err := ParseFieldPath(selector.Field)
+	if err != nil {
+		return 0
 	}
 
-	// If multiple matches, return the first one
-	// In a real implementation, you might want to implement more sophisticated ranking
-	return matches[0], nil
+	best := 0
+	for _, fieldValue := range path.Evaluate(pipelineFields(pipeline)) {
+		if fieldValue == nil || !s.matchesValue(fieldValue, selector) {
+			continue
+		}
+		weight := selectorSpecificity[selector.Operator]
+		if s.compareValues(fieldValue, selector.Value, "equals") {
+			weight = selectorSpecificity[PipelineSelectorOperatorEquals]
+		}
+		if path.HasWildcard() {
+			weight--
+		}
+		if weight > best {
+			best = weight
+		}
+	}
+	return best
 }
 
 // matchesSelectors checks if a pipeline matches all the given selectors
@@ -90,13 +240,24 @@ func (s *PipelineSelectorService) matchesSelectors(pipeline *PipelineInfo, selec
 	return true
 }
 
-// matchesSelector checks if a pipeline matches a single selector
+// matchesSelector checks if a pipeline matches a single selector. A selector whose
+// field path reaches several values matches when any of them does.
 func (s *PipelineSelectorService) matchesSelector(pipeline *PipelineInfo, selector PipelineSelector) bool {
-	fieldValue := s.extractFieldValue(pipeline, selector.Field)
-	if fieldValue == nil {
+	path, err := ParseFieldPath(selector.Field)
+	if err != nil {
 		return false
 	}
 
+	for _, fieldValue := range path.Evaluate(pipelineFields(pipeline)) {
+		if fieldValue != nil && s.matchesValue(fieldValue, selector) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesValue applies a selector's operator to a single field value
+func (s *PipelineSelectorService) matchesValue(fieldValue interface{}, selector PipelineSelector) bool {
 	switch selector.Operator {
 	case PipelineSelectorOperatorEquals:
 		return s.compareValues(fieldValue, selector.Value, "equals")
@@ -113,34 +274,15 @@ func (s *PipelineSelectorService) matchesSelector(pipeline *PipelineInfo, select
 	}
 }
 
-// extractFieldValue extracts a field value from pipeline info based on a dot-separated path
-func (s *PipelineSelectorService) extractFieldValue(pipeline *PipelineInfo, fieldPath string) interface{} {
-	parts := strings.Split(fieldPath, ".")
-	if len(parts) == 0 {
-		return nil
-	}
-
-	switch parts[0] {
-	case "id":
-		return pipeline.ID
-	case "name":
-		return pipeline.Name
-	case "description":
-		return pipeline.Description
-	case "type":
-		return pipeline.Type
-	case "tags":
-		if len(parts) > 1 {
-			return pipeline.Tags[parts[1]]
-		}
-		return nil
-	case "metadata":
-		if len(parts) > 1 {
-			return pipeline.Metadata[parts[1]]
-		}
-		return nil
-	default:
-		return nil
+// pipelineFields exposes pipeline info to selector field paths
+func pipelineFields(pipeline *PipelineInfo) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          pipeline.ID,
+		"name":        pipeline.Name,
+		"description": pipeline.Description,
+		"type":        pipeline.Type,
+		"tags":        pipeline.Tags,
+		"metadata":    pipeline.Metadata,
 	}
 }
 
@@ -252,11 +394,14 @@ func (s *PipelineSelectorService) GetPipelineByID(id string) (*PipelineInfo, err
 	return pipeline, nil
 }
 
-// ListPipelines returns all registered pipelines
+// ListPipelines returns all registered pipelines, sorted by ID
 func (s *PipelineSelectorService) ListPipelines() []*PipelineInfo {
 	pipelines := make([]*PipelineInfo, 0, len(s.pipelines))
 	for _, pipeline := range s.pipelines {
 		pipelines = append(pipelines, pipeline)
 	}
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].ID < pipelines[j].ID
+	})
 	return pipelines
 }
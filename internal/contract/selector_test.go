@@ -4,6 +4,8 @@
 package contract
 
 import (
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -236,3 +238,155 @@ func TestPipelineSelectorService(t *testing.T) {
 		}
 	})
 }
+
+func TestPipelineSelectorService_Ranking(t *testing.T) {
+	newService := func(priorities map[string]int) *PipelineSelectorService {
+		service := NewPipelineSelectorService()
+		for _, id := range []string{"traces/c", "traces/a", "traces/b", "metrics"} {
+			pipelineType := "trace"
+			if id == "metrics" {
+				pipelineType = "metric"
+			}
+			service.RegisterPipeline(&PipelineInfo{ID: id, Name: id, Type: pipelineType, Priority: priorities[id]})
+		}
+		return service
+	}
+	selectors := func(match PipelineMatchPolicy) *PipelineSelectors {
+		return &PipelineSelectors{
+			Selectors: []PipelineSelector{{Field: "type", Operator: PipelineSelectorOperatorEquals, Value: "trace"}},
+			Match:     match,
+		}
+	}
+
+	t.Run("Deterministic Order", func(t *testing.T) {
+		service := newService(map[string]int{"traces/b": 2})
+		for i := 0; i < 20; i++ {
+			matches, err := service.RankPipelines(selectors(""))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, match.Pipeline.ID)
+			}
+			if got := strings.Join(ids, ","); got != "traces/b,traces/a,traces/c" {
+				t.Fatalf("Expected priority then ID order, got %s", got)
+			}
+			if matches[0].Priority != 2 || matches[0].Specificity != 4 {
+				t.Errorf("Expected traces/b to score priority 2, specificity 4, got %s", matches[0])
+			}
+		}
+	})
+
+	t.Run("Best", func(t *testing.T) {
+		pipelines, err := newService(map[string]int{"traces/c": 5}).SelectPipelines(selectors(PipelineMatchBest))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(pipelines) != 1 || pipelines[0].ID != "traces/c" {
+			t.Errorf("Expected the highest priority pipeline traces/c, got %v", pipelines)
+		}
+
+		_, err = newService(map[string]int{"traces/a": 5, "traces/c": 5}).SelectPipelines(selectors(PipelineMatchBest))
+		var ambiguous *AmbiguousPipelineError
+		if !errors.As(err, &ambiguous) {
+			t.Fatalf("Expected an ambiguity error, got %v", err)
+		}
+		want := "ambiguous pipeline selection: 2 pipelines tie for the best match: traces/a (priority 5, specificity 4), traces/c (priority 5, specificity 4)"
+		if err.Error() != want {
+			t.Errorf("Expected %q, got %q", want, err.Error())
+		}
+	})
+
+	t.Run("Exactly One", func(t *testing.T) {
+		_, err := newService(map[string]int{"traces/c": 5}).SelectPipelines(selectors(PipelineMatchExactlyOne))
+		if err == nil || !strings.Contains(err.Error(), "expected exactly one matching pipeline, found 3: traces/c") {
+			t.Errorf("Expected an error listing the 3 candidates, got %v", err)
+		}
+
+		metrics := selectors(PipelineMatchExactlyOne)
+		metrics.Selectors[0].Value = "metric"
+		pipelines, err := newService(nil).SelectPipelines(metrics)
+		if err != nil || len(pipelines) != 1 || pipelines[0].ID != "metrics" {
+			t.Errorf("Expected the only metrics pipeline, got %v, %v", pipelines, err)
+		}
+	})
+
+	t.Run("Minimum Priority", func(t *testing.T) {
+		// traces/a matches more specifically, traces/c has the higher pipeline priority
+		service := NewPipelineSelectorService()
+		service.RegisterPipelines([]*PipelineInfo{
+			{ID: "traces/a", Name: "auth", Type: "trace", Priority: 1},
+			{ID: "traces/c", Name: "auth-legacy", Type: "trace", Priority: 3},
+		})
+		auth := func(priority int) *PipelineSelectors {
+			return &PipelineSelectors{
+				Selectors: []PipelineSelector{{Field: "name", Operator: PipelineSelectorOperatorContains, Value: "auth"}},
+				Priority:  priority,
+				Match:     PipelineMatchExactlyOne,
+			}
+		}
+
+		for _, priority := range []int{0, 1} {
+			if _, err := service.SelectPipelines(auth(priority)); err == nil {
+				t.Errorf("Priority %d: expected both pipelines to be candidates", priority)
+			}
+		}
+		pipelines, err := service.SelectPipelines(auth(2))
+		if err != nil || len(pipelines) != 1 || pipelines[0].ID != "traces/c" {
+			t.Errorf("Expected priority 2 to leave only traces/c, got %v, %v", pipelines, err)
+		}
+		if pipelines, err := service.SelectPipelines(auth(4)); err != nil || len(pipelines) != 0 {
+			t.Errorf("Expected no pipeline to reach priority 4, got %v, %v", pipelines, err)
+		}
+	})
+
+	t.Run("Specificity", func(t *testing.T) {
+		service := newService(nil)
+		exact, _ := service.RankPipelines(&PipelineSelectors{
+			Selectors: []PipelineSelector{{Field: "id", Operator: PipelineSelectorOperatorEquals, Value: "traces/a"}},
+		})
+		pattern, _ := service.RankPipelines(&PipelineSelectors{
+			Selectors: []PipelineSelector{{Field: "id", Operator: PipelineSelectorOperatorMatches, Value: "^traces/a$"}},
+		})
+		if len(exact) != 1 || len(pattern) != 1 || exact[0].Specificity <= pattern[0].Specificity {
+			t.Errorf("Expected equals to be more specific than matches, got %v and %v", exact, pattern)
+		}
+	})
+
+	t.Run("Specificity Per Pipeline", func(t *testing.T) {
+		service := NewPipelineSelectorService()
+		service.RegisterPipelines([]*PipelineInfo{
+			{ID: "traces/auth-legacy", Name: "auth-legacy", Type: "trace"},
+			{ID: "traces/auth", Name: "auth", Type: "trace"},
+			{ID: "traces/billing", Name: "billing", Type: "trace", Specificity: 4},
+		})
+		contains := &PipelineSelectors{
+			Selectors: []PipelineSelector{{Field: "name", Operator: PipelineSelectorOperatorContains, Value: "auth"}},
+			Match:     PipelineMatchBest,
+		}
+
+		matches, err := service.RankPipelines(contains)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(matches) != 2 || matches[0].Pipeline.ID != "traces/auth" ||
+			matches[0].Specificity != 4 || matches[1].Specificity != 2 {
+			t.Fatalf("Expected the exact name match to rank first, got %v", matches)
+		}
+		pipelines, err := service.SelectPipelines(contains)
+		if err != nil || len(pipelines) != 1 || pipelines[0].ID != "traces/auth" {
+			t.Errorf("Expected best to pick traces/auth, got %v, %v", pipelines, err)
+		}
+
+		// The pipeline's own specificity from the runner configuration breaks the tie
+		traces := &PipelineSelectors{
+			Selectors: []PipelineSelector{{Field: "type", Operator: PipelineSelectorOperatorEquals, Value: "trace"}},
+			Match:     PipelineMatchBest,
+		}
+		pipelines, err = service.SelectPipelines(traces)
+		if err != nil || len(pipelines) != 1 || pipelines[0].ID != "traces/billing" {
+			t.Errorf("Expected best to pick traces/billing, got %v, %v", pipelines, err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"gopkg.in/yaml.v3"
+)
+
+// SeverityMatcher checks the severity of a log record by text, by number or by a
+// number range. In YAML it is either a scalar severity text or a mapping
+// {text, number, min, max}; min and max take a severity name such as WARN or a number.
+type SeverityMatcher struct {
+	Text   string `yaml:"text,omitempty"`   // Exact severity text
+	Number int32  `yaml:"number,omitempty"` // Exact severity number
+	Min    string `yaml:"min,omitempty"`    // Lowest allowed severity
+	Max    string `yaml:"max,omitempty"`    // Highest allowed severity
+}
+
+// UnmarshalYAML accepts both the scalar text and the mapping forms
+func (s *SeverityMatcher) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*s = SeverityMatcher{Text: node.Value}
+		return nil
+	case yaml.MappingNode:
+		type plain SeverityMatcher
+		var raw plain
+		if err := node.Decode(&raw); err != nil {
+			return err
+		}
+		*s = SeverityMatcher(raw)
+		return nil
+	default:
+		return fmt.Errorf("severity must be a string or a {text, number, min, max} mapping")
+	}
+}
+
+// IsZero reports whether the matcher checks nothing
+func (s SeverityMatcher) IsZero() bool {
+	return s == SeverityMatcher{}
+}
+
+// Validate checks the severity number and range
+func (s SeverityMatcher) Validate() error {
+	if s.Number != 0 && (s.Number < int32(plog.SeverityNumberTrace) || s.Number > int32(plog.SeverityNumberFatal4)) {
+		return fmt.Errorf("number %d out of range 1-24", s.Number)
+	}
+	_, _, err := s.Range()
+	return err
+}
+
+// Range returns the inclusive severity number range allowed by min and max. A bare
+// severity name as max covers its whole range, so max: WARN allows WARN4.
+func (s SeverityMatcher) Range() (plog.SeverityNumber, plog.SeverityNumber, error) {
+	low, high := plog.SeverityNumberTrace, plog.SeverityNumberFatal4
+	if s.Min != "" {
+		number, err := ParseSeverityNumber(s.Min)
+		if err != nil {
+			return 0, 0, fmt.Errorf("min: %w", err)
+		}
+		low = number
+	}
+	if s.Max != "" {
+		number, err := ParseSeverityNumber(s.Max)
+		if err != nil {
+			return 0, 0, fmt.Errorf("max: %w", err)
+		}
+		high = number
+		if last := s.Max[len(s.Max)-1]; last < '0' || last > '9' {
+			high = number + 3
+		}
+	}
+	if low > high {
+		return 0, 0, fmt.Errorf("min %s is above max %s", s.Min, s.Max)
+	}
+	return low, high, nil
+}
+
+// ParseSeverityNumber parses a severity name such as WARN, WARNING or ERROR2, or a
+// severity number from 1 to 24
+func ParseSeverityNumber(severity string) (plog.SeverityNumber, error) {
+	if number, err := strconv.Atoi(severity); err == nil {
+		if number < int(plog.SeverityNumberTrace) || number > int(plog.SeverityNumberFatal4) {
+			return 0, fmt.Errorf("severity number %d out of range 1-24", number)
+		}
+		return plog.SeverityNumber(number), nil
+	}
+
+	name := strings.ToUpper(strings.TrimSpace(severity))
+	if strings.HasPrefix(name, "WARNING") {
+		name = "WARN" + strings.TrimPrefix(name, "WARNING")
+	}
+	for number := plog.SeverityNumberTrace; number <= plog.SeverityNumberFatal4; number++ {
+		if strings.ToUpper(number.String()) == name {
+			return number, nil
+		}
+	}
+	if strings.HasSuffix(name, "1") {
+		return ParseSeverityNumber(strings.TrimSuffix(name, "1"))
+	}
+	return 0, fmt.Errorf("unknown severity %q", severity)
+}
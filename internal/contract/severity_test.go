@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package contract
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseSeverityNumber(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     plog.SeverityNumber
+	}{
+		{"WARN", plog.SeverityNumberWarn},
+		{"warning", plog.SeverityNumberWarn},
+		{"Error2", plog.SeverityNumberError2},
+		{"ERROR1", plog.SeverityNumberError},
+		{"21", plog.SeverityNumberFatal},
+	}
+	for _, tt := range tests {
+		got, err := ParseSeverityNumber(tt.severity)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.severity, err)
+		} else if got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.severity, tt.want, got)
+		}
+	}
+
+	for _, severity := range []string{"LOUD", "0", "25", "WARN5"} {
+		if _, err := ParseSeverityNumber(severity); err == nil {
+			t.Errorf("Expected %q to be rejected", severity)
+		}
+	}
+}
+
+func TestSeverityMatcher_Range(t *testing.T) {
+	low, high, err := SeverityMatcher{Min: "WARN", Max: "ERROR"}.Range()
+	if err != nil {
+		t.Fatalf("Range failed: %v", err)
+	}
+	if low != plog.SeverityNumberWarn || high != plog.SeverityNumberError4 {
+		t.Errorf("Expected WARN-ERROR4, got %s-%s", low, high)
+	}
+
+	if _, high, _ = (SeverityMatcher{Max: "ERROR2"}).Range(); high != plog.SeverityNumberError2 {
+		t.Errorf("Expected an explicit level to bound the range exactly, got %s", high)
+	}
+
+	if err := (SeverityMatcher{Min: "ERROR", Max: "WARN"}).Validate(); err == nil {
+		t.Error("Expected min above max to be rejected")
+	}
+	if err := (SeverityMatcher{Number: 30}).Validate(); err == nil {
+		t.Error("Expected out of range number to be rejected")
+	}
+}
+
+func TestSeverityMatcher_UnmarshalYAML(t *testing.T) {
+	var matchers []LogMatcher
+	input := `
+- severity: ERROR
+- severity: {min: WARN}
+`
+	if err := yaml.Unmarshal([]byte(input), &matchers); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if matchers[0].Severity != (SeverityMatcher{Text: "ERROR"}) {
+		t.Errorf("Expected scalar severity to set text, got %+v", matchers[0].Severity)
+	}
+	if matchers[1].Severity != (SeverityMatcher{Min: "WARN"}) {
+		t.Errorf("Expected mapping severity to set min, got %+v", matchers[1].Severity)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"gopkg.in/yaml.v3"
 )
 
 // SignalType represents the type of telemetry signal
@@ -64,10 +65,20 @@ type PipelineSelector struct {
 	Value    interface{}              `yaml:"value"`
 }
 
+// PipelineMatchPolicy decides which of the pipelines matching a contract's selectors it runs against
+type PipelineMatchPolicy string
+
+const (
+	PipelineMatchAll        PipelineMatchPolicy = "all"         // Every matching pipeline (the default)
+	PipelineMatchBest       PipelineMatchPolicy = "best"        // The highest scoring pipeline, which must be unique
+	PipelineMatchExactlyOne PipelineMatchPolicy = "exactly_one" // The only matching pipeline
+)
+
 // PipelineSelectors represents a set of criteria for matching pipelines
 type PipelineSelectors struct {
-	Selectors []PipelineSelector `yaml:"selectors,omitempty"`
-	Priority  int                `yaml:"priority,omitempty"` // Higher priority selectors are preferred
+	Selectors []PipelineSelector  `yaml:"selectors,omitempty"`
+	Priority  int                 `yaml:"priority,omitempty"` // Minimum priority a matching pipeline must have, when positive
+	Match     PipelineMatchPolicy `yaml:"match,omitempty"`
 }
 
 // ValidationRule represents a sophisticated validation rule
@@ -153,14 +164,37 @@ type TraceInput struct {
 	Attributes  map[string]interface{} `yaml:"attributes,omitempty"`
 	ParentSpan  string                 `yaml:"parent_span,omitempty"`
 	ServiceName string                 `yaml:"service_name,omitempty"`
+	Events      []SpanEventInput       `yaml:"events,omitempty"`
+	Links       []SpanLinkInput        `yaml:"links,omitempty"`
+	Expect      ExpectMode             `yaml:"expect,omitempty"` // Overrides matchers.expect.traces for this span
+}
+
+// SpanEventInput represents an event recorded on an input span
+type SpanEventInput struct {
+	Name       string                 `yaml:"name"`
+	Attributes map[string]interface{} `yaml:"attributes,omitempty"`
+}
+
+// SpanLinkInput represents a link from an input span to another named input span
+type SpanLinkInput struct {
+	Span       string                 `yaml:"span"`
+	Attributes map[string]interface{} `yaml:"attributes,omitempty"`
 }
 
 // MetricInput represents input metric data
 type MetricInput struct {
-	Name   string                 `yaml:"name"`
-	Value  interface{}            `yaml:"value"`
-	Type   string                 `yaml:"type,omitempty"` // counter, gauge, histogram
-	Labels map[string]interface{} `yaml:"labels,omitempty"`
+	Name      string                 `yaml:"name"`
+	Value     interface{}            `yaml:"value"`
+	Type      string                 `yaml:"type,omitempty"` // counter, gauge, histogram
+	Labels    map[string]interface{} `yaml:"labels,omitempty"`
+	Exemplars []ExemplarInput        `yaml:"exemplars,omitempty"` // Exemplars recorded on the data point
+	Expect    ExpectMode             `yaml:"expect,omitempty"`    // Overrides matchers.expect.metrics for this metric
+}
+
+// ExemplarInput represents an exemplar referencing a named input span
+type ExemplarInput struct {
+	Span  string      `yaml:"span"`            // Input span whose trace and span IDs the exemplar carries
+	Value interface{} `yaml:"value,omitempty"` // Exemplar value, defaulting to the data point value
 }
 
 // LogInput represents input log data
@@ -168,6 +202,85 @@ type LogInput struct {
 	Body       string                 `yaml:"body"`
 	Severity   string                 `yaml:"severity,omitempty"`
 	Attributes map[string]interface{} `yaml:"attributes,omitempty"`
+	Span       string                 `yaml:"span,omitempty"`   // Input span whose trace context the log carries
+	Expect     ExpectMode             `yaml:"expect,omitempty"` // Overrides matchers.expect.logs for this log
+}
+
+// ExpectMode is the expected outcome of an input item after it passes through the pipeline
+type ExpectMode string
+
+const (
+	ExpectDropped       ExpectMode = "dropped"        // The item must not appear in the output
+	ExpectPassedThrough ExpectMode = "passed_through" // The item must appear in the output, possibly modified
+	ExpectUnchanged     ExpectMode = "unchanged"      // The item must appear in the output unmodified
+)
+
+// Expectations sets the expected outcome of every input item of a signal
+type Expectations struct {
+	Traces  ExpectMode `yaml:"traces,omitempty"`
+	Metrics ExpectMode `yaml:"metrics,omitempty"`
+	Logs    ExpectMode `yaml:"logs,omitempty"`
+}
+
+// QuantifierMode represents how many output items must satisfy a matcher
+type QuantifierMode string
+
+const (
+	QuantifierAny     QuantifierMode = "any"
+	QuantifierAll     QuantifierMode = "all"
+	QuantifierNone    QuantifierMode = "none"
+	QuantifierExactly QuantifierMode = "exactly"
+)
+
+// Quantifier selects how many candidate items must satisfy a matcher.
+// In YAML it is either a scalar (any, all, none) or a mapping {exactly: N}.
+type Quantifier struct {
+	Mode  QuantifierMode
+	Count int // Only used with QuantifierExactly
+}
+
+// UnmarshalYAML accepts both the scalar and the {exactly: N} forms
+func (q *Quantifier) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if QuantifierMode(node.Value) == QuantifierExactly {
+			return fmt.Errorf("quantifier exactly requires a count, use {exactly: N}")
+		}
+		q.Mode = QuantifierMode(node.Value)
+		q.Count = 0
+		return nil
+	case yaml.MappingNode:
+		var raw struct {
+			Exactly *int `yaml:"exactly"`
+		}
+		if err := node.Decode(&raw); err != nil {
+			return err
+		}
+		if raw.Exactly == nil {
+			return fmt.Errorf("quantifier mapping must specify exactly")
+		}
+		q.Mode = QuantifierExactly
+		q.Count = *raw.Exactly
+		return nil
+	default:
+		return fmt.Errorf("quantifier must be a string or an {exactly: N} mapping")
+	}
+}
+
+// GetMode returns the quantifier mode, defaulting to any
+func (q Quantifier) GetMode() QuantifierMode {
+	if q.Mode == "" {
+		return QuantifierAny
+	}
+	return q.Mode
+}
+
+// String returns a human-readable form of the quantifier
+func (q Quantifier) String() string {
+	if q.GetMode() == QuantifierExactly {
+		return fmt.Sprintf("exactly %d", q.Count)
+	}
+	return string(q.GetMode())
 }
 
 // TraceMatcher represents expected trace transformations
@@ -176,10 +289,15 @@ type TraceMatcher struct {
 	Attributes       map[string]interface{}   `yaml:"attributes,omitempty"`
 	ParentSpan       string                   `yaml:"parent_span,omitempty"`
 	ServiceName      string                   `yaml:"service_name,omitempty"`
+	Quantifier       Quantifier               `yaml:"quantifier,omitempty"`        // How many spans must match (default any)
 	ValidationRules  []ValidationRule         `yaml:"validation_rules,omitempty"`  // Advanced validation rules
 	Count            *CountMatcher            `yaml:"count,omitempty"`             // Expected count validation
 	Duration         *DurationMatcher         `yaml:"duration,omitempty"`          // Span duration validation
 	StatusCode       *StatusCodeMatcher       `yaml:"status_code,omitempty"`       // HTTP/gRPC status validation
+	Resource         *ResourceMatcher         `yaml:"resource,omitempty"`          // Resource attributes and schema URL
+	Scope            *ScopeMatcher            `yaml:"scope,omitempty"`             // Instrumentation scope validation
+	Events           []SpanEventMatcher       `yaml:"events,omitempty"`            // Span event validation
+	Links            []SpanLinkMatcher        `yaml:"links,omitempty"`             // Span link validation
 	CustomValidation *CustomValidationMatcher `yaml:"custom_validation,omitempty"` // Custom validation logic
 }
 
@@ -188,25 +306,68 @@ type MetricMatcher struct {
 	Name             string                   `yaml:"name,omitempty"`
 	Type             string                   `yaml:"type,omitempty"`
 	Labels           map[string]interface{}   `yaml:"labels,omitempty"`
+	Quantifier       Quantifier               `yaml:"quantifier,omitempty"`        // How many metrics must match (default any)
 	ValidationRules  []ValidationRule         `yaml:"validation_rules,omitempty"`  // Advanced validation rules
 	Value            *ValueMatcher            `yaml:"value,omitempty"`             // Metric value validation
 	Count            *CountMatcher            `yaml:"count,omitempty"`             // Expected count validation
 	Histogram        *HistogramMatcher        `yaml:"histogram,omitempty"`         // Histogram-specific validation
+	Resource         *ResourceMatcher         `yaml:"resource,omitempty"`          // Resource attributes and schema URL
+	Scope            *ScopeMatcher            `yaml:"scope,omitempty"`             // Instrumentation scope validation
 	CustomValidation *CustomValidationMatcher `yaml:"custom_validation,omitempty"` // Custom validation logic
 }
 
 // LogMatcher represents expected log transformations
 type LogMatcher struct {
 	Body             string                   `yaml:"body,omitempty"`
-	Severity         string                   `yaml:"severity,omitempty"`
+	BodyMatches      string                   `yaml:"body_matches,omitempty"` // Regular expression the body must match
+	BodyFields       map[string]interface{}   `yaml:"body_fields,omitempty"`  // Expected values of structured body fields by path
+	ParseJSON        bool                     `yaml:"parse_json,omitempty"`   // Parse string bodies as JSON before checking body_fields
+	Severity         SeverityMatcher          `yaml:"severity,omitempty"`
 	Attributes       map[string]interface{}   `yaml:"attributes,omitempty"`
+	Quantifier       Quantifier               `yaml:"quantifier,omitempty"`        // How many log records must match (default any)
 	ValidationRules  []ValidationRule         `yaml:"validation_rules,omitempty"`  // Advanced validation rules
 	Count            *CountMatcher            `yaml:"count,omitempty"`             // Expected count validation
 	Timestamp        *TimestampMatcher        `yaml:"timestamp,omitempty"`         // Timestamp validation
+	Resource         *ResourceMatcher         `yaml:"resource,omitempty"`          // Resource attributes and schema URL
+	Scope            *ScopeMatcher            `yaml:"scope,omitempty"`             // Instrumentation scope validation
 	CustomValidation *CustomValidationMatcher `yaml:"custom_validation,omitempty"` // Custom validation logic
 }
 
-// CountMatcher represents count-based validation
+// SpanEventMatcher represents expectations on the events recorded on a span.
+// Without a count, at least one event must match; with a count, the number of
+// matching events is checked instead.
+type SpanEventMatcher struct {
+	Name       string                 `yaml:"name,omitempty"`
+	Attributes map[string]interface{} `yaml:"attributes,omitempty"`
+	Count      *CountMatcher          `yaml:"count,omitempty"`
+}
+
+// SpanLinkMatcher represents expectations on the links of a span. Span names a
+// contract input span whose trace and span IDs the link must reference.
+type SpanLinkMatcher struct {
+	Span       string                 `yaml:"span,omitempty"`
+	TraceID    string                 `yaml:"trace_id,omitempty"`
+	SpanID     string                 `yaml:"span_id,omitempty"`
+	Attributes map[string]interface{} `yaml:"attributes,omitempty"`
+	Count      *CountMatcher          `yaml:"count,omitempty"`
+}
+
+// ResourceMatcher represents expectations on the resource an item was emitted under
+type ResourceMatcher struct {
+	Attributes map[string]interface{} `yaml:"attributes,omitempty"`
+	SchemaURL  string                 `yaml:"schema_url,omitempty"`
+}
+
+// ScopeMatcher represents expectations on the instrumentation scope an item was emitted under
+type ScopeMatcher struct {
+	Name       string                 `yaml:"name,omitempty"`
+	Version    string                 `yaml:"version,omitempty"`
+	Attributes map[string]interface{} `yaml:"attributes,omitempty"`
+	SchemaURL  string                 `yaml:"schema_url,omitempty"`
+}
+
+// CountMatcher represents count-based validation.
+// In YAML it is either a scalar expected count or a mapping.
 type CountMatcher struct {
 	Expected int            `yaml:"expected,omitempty"`
 	Min      *int           `yaml:"min,omitempty"`
@@ -215,6 +376,29 @@ type CountMatcher struct {
 	Value    int            `yaml:"value,omitempty"`
 }
 
+// UnmarshalYAML accepts both the scalar count and the mapping forms
+func (c *CountMatcher) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		var expected int
+		if err := node.Decode(&expected); err != nil {
+			return fmt.Errorf("count must be an integer or a {expected, min, max, operator, value} mapping")
+		}
+		*c = CountMatcher{Expected: expected}
+		return nil
+	case yaml.MappingNode:
+		type plain CountMatcher
+		var raw plain
+		if err := node.Decode(&raw); err != nil {
+			return err
+		}
+		*c = CountMatcher(raw)
+		return nil
+	default:
+		return fmt.Errorf("count must be an integer or a {expected, min, max, operator, value} mapping")
+	}
+}
+
 // ValueMatcher represents metric value validation
 type ValueMatcher struct {
 	Expected  interface{}    `yaml:"expected,omitempty"`
@@ -242,8 +426,8 @@ type StatusCodeMatcher struct {
 // HistogramMatcher represents histogram-specific validation
 type HistogramMatcher struct {
 	Buckets      []float64       `yaml:"buckets,omitempty"`
-	Count        int             `yaml:"count,omitempty"`
-	Sum          float64         `yaml:"sum,omitempty"`
+	Count        *int            `yaml:"count,omitempty"` // nil when the count is not checked
+	Sum          *float64        `yaml:"sum,omitempty"`   // nil when the sum is not checked
 	BucketCounts map[float64]int `yaml:"bucket_counts,omitempty"`
 }
 
@@ -272,9 +456,110 @@ type Inputs struct {
 
 // Matchers represents expected transformation matchers
 type Matchers struct {
-	Traces  []TraceMatcher  `yaml:"traces,omitempty"`
-	Metrics []MetricMatcher `yaml:"metrics,omitempty"`
-	Logs    []LogMatcher    `yaml:"logs,omitempty"`
+	Traces         []TraceMatcher         `yaml:"traces,omitempty"`
+	Metrics        []MetricMatcher        `yaml:"metrics,omitempty"`
+	Logs           []LogMatcher           `yaml:"logs,omitempty"`
+	TraceStructure *TraceStructureMatcher `yaml:"trace_structure,omitempty"` // Span tree topology validation
+	Comparison     *CompareOptions        `yaml:"comparison,omitempty"`      // Overrides how attribute values are compared
+	Expect         *Expectations          `yaml:"expect,omitempty"`          // Expected outcome of input items per signal
+	Golden         *GoldenMatcher         `yaml:"golden,omitempty"`          // Golden OTLP JSON snapshot of the output
+	Semconv        *SemconvMatcher        `yaml:"semconv,omitempty"`         // Semantic convention conformance of the output
+	Cardinality    []CardinalityMatcher   `yaml:"cardinality,omitempty"`     // Bounds on the attribute sets of output metrics
+	Correlations   []CorrelationMatcher   `yaml:"correlations,omitempty"`    // References from logs and exemplars to output spans
+	Batches        *BatchMatcher          `yaml:"batches,omitempty"`         // Number of batches delivered to the exporters
+}
+
+// BatchMatcher asserts how output was split into batches on its way to the exporters
+type BatchMatcher struct {
+	Signal SignalType    `yaml:"signal,omitempty"` // Only count batches of this signal, every signal when empty
+	Count  *CountMatcher `yaml:"count"`            // Number of batches
+}
+
+// CorrelationSource is the kind of output item whose span reference is checked
+type CorrelationSource string
+
+const (
+	CorrelationSourceLogs      CorrelationSource = "logs"      // Log record trace_id and span_id
+	CorrelationSourceExemplars CorrelationSource = "exemplars" // Metric exemplar trace_id and span_id
+)
+
+// CorrelationMatcher asserts that every selected log record or exemplar references a
+// span present in the output
+type CorrelationMatcher struct {
+	Source CorrelationSource `yaml:"source"`           // logs or exemplars
+	Body   string            `yaml:"body,omitempty"`   // Only check log records with this body
+	Metric string            `yaml:"metric,omitempty"` // Only check exemplars of this metric
+	Span   string            `yaml:"span,omitempty"`   // Input span that must be referenced, any output span when empty
+}
+
+// CardinalityMatcher bounds the distinct attribute sets of a metric across every
+// data point in the output
+type CardinalityMatcher struct {
+	Metric              string   `yaml:"metric"`                         // Metric name
+	Max                 *int     `yaml:"max,omitempty"`                  // Maximum number of distinct attribute sets
+	ForbiddenDimensions []string `yaml:"forbidden_dimensions,omitempty"` // Attributes that must not appear on any data point
+}
+
+// SemconvLevel selects which semantic convention attributes must be present
+type SemconvLevel string
+
+const (
+	SemconvLevelRequired    SemconvLevel = "required"    // Required attributes only
+	SemconvLevelRecommended SemconvLevel = "recommended" // Required and recommended attributes
+)
+
+// SemconvMatcher checks output against a bundled version of the OpenTelemetry semantic conventions
+type SemconvMatcher struct {
+	Version string       `yaml:"version"`         // Semantic convention version, such as 1.26.0
+	Level   SemconvLevel `yaml:"level,omitempty"` // Attribute requirement level (default required)
+}
+
+// GetLevel returns the requirement level, defaulting to required
+func (m *SemconvMatcher) GetLevel() SemconvLevel {
+	if m.Level == "" {
+		return SemconvLevelRequired
+	}
+	return m.Level
+}
+
+// GoldenMatcher compares the normalised output against a golden OTLP JSON snapshot
+type GoldenMatcher struct {
+	File string     `yaml:"file"`           // Snapshot path, relative to the contract file
+	Mask GoldenMask `yaml:"mask,omitempty"` // Volatile fields masked before comparison
+}
+
+// GoldenMask selects the volatile fields masked before output is compared with a snapshot
+type GoldenMask struct {
+	IDs        *bool    `yaml:"ids,omitempty"`        // Replace trace and span IDs with stable placeholders (default true)
+	Timestamps *bool    `yaml:"timestamps,omitempty"` // Mask every *TimeUnixNano field (default true)
+	Attributes []string `yaml:"attributes,omitempty"` // Attribute keys whose values are masked
+	Fields     []string `yaml:"fields,omitempty"`     // OTLP JSON field names whose values are masked
+}
+
+// MaskIDs reports whether trace and span IDs are masked
+func (m GoldenMask) MaskIDs() bool {
+	return m.IDs == nil || *m.IDs
+}
+
+// MaskTimestamps reports whether timestamps are masked
+func (m GoldenMask) MaskTimestamps() bool {
+	return m.Timestamps == nil || *m.Timestamps
+}
+
+// TraceStructureMatcher represents expectations on the span trees rebuilt from output traces
+type TraceStructureMatcher struct {
+	TraceCount    *CountMatcher `yaml:"trace_count,omitempty"`     // Number of distinct traces
+	SpansPerTrace *CountMatcher `yaml:"spans_per_trace,omitempty"` // Number of spans in every trace
+	RootSpan      string        `yaml:"root_span,omitempty"`       // Name of every trace's root span
+	Edges         []SpanEdge    `yaml:"edges,omitempty"`           // Required parent/child relationships
+	AllowOrphans  bool          `yaml:"allow_orphans,omitempty"`   // Permit spans whose parent is missing
+	MaxDepth      int           `yaml:"max_depth,omitempty"`       // Maximum depth of any trace
+}
+
+// SpanEdge represents a parent/child relationship between two named spans
+type SpanEdge struct {
+	Parent string `yaml:"parent"`
+	Child  string `yaml:"child"`
 }
 
 // Contract represents a complete contract definition
@@ -284,6 +569,7 @@ type Contract struct {
 	PipelineSelectors *PipelineSelectors   `yaml:"pipeline_selectors,omitempty"` // Pipeline matching criteria
 	Version           string               `yaml:"version"`
 	Description       string               `yaml:"description,omitempty"`
+	Timeout           string               `yaml:"timeout,omitempty"` // Overrides the runner's per-contract timeout, such as 5s
 	Inputs            Inputs               `yaml:"inputs"`
 	Filters           []Filter             `yaml:"filters,omitempty"`          // Legacy filters (for backward compatibility)
 	ValidationRules   []ValidationRule     `yaml:"validation_rules,omitempty"` // Advanced validation rules
@@ -329,13 +615,22 @@ type OpenTelemetryData struct {
 	Metrics pmetric.Metrics
 	Logs    plog.Logs
 	Time    time.Time
+	Batches []Batch // Batches the output was delivered in, in order; the signals above merge them
+}
+
+// Batch describes one batch delivered to the exporters
+type Batch struct {
+	Signal SignalType
+	Items  int // Spans, data points or log records in the batch
 }
 
 // ValidationResult represents the result of contract validation
 type ValidationResult struct {
-	Valid    bool
-	Errors   []ValidationError
-	Warnings []string
+	Valid      bool
+	Skipped    bool
+	SkipReason string
+	Errors     []ValidationError
+	Warnings   []string
 }
 
 // ValidationError represents a specific validation error
@@ -347,6 +642,7 @@ type ValidationError struct {
 	Actual     interface{}
 	SignalType SignalType
 	Index      int
+	Diff       *TelemetryDiff // Expected shape against the closest actual item, when available
 }
 
 // Contract interface for validation
@@ -378,8 +674,19 @@ func (c *Contract) Validate(input, output OpenTelemetryData) ValidationResult {
 	}
 
 	// Apply filters to determine if this contract should be validated
-	if !c.applyFilters(input) {
+	applies, reason, err := c.applyFilters(input)
+	if err != nil {
+		result.Valid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Type:    "filter",
+			Message: err.Error(),
+		})
+		return result
+	}
+	if !applies {
 		// Contract doesn't apply to this data, but that's not an error
+		result.Skipped = true
+		result.SkipReason = reason
 		return result
 	}
 
@@ -427,32 +734,83 @@ func (c *Contract) validateContractStructure() error {
 	if len(c.Inputs.Traces) == 0 && len(c.Inputs.Metrics) == 0 && len(c.Inputs.Logs) == 0 {
 		return fmt.Errorf("at least one input (traces, metrics, or logs) must be specified")
 	}
-	if len(c.Matchers.Traces) == 0 && len(c.Matchers.Metrics) == 0 && len(c.Matchers.Logs) == 0 {
-		return fmt.Errorf("at least one matcher (traces, metrics, or logs) must be specified")
+	if !c.hasMatchers() {
+		return fmt.Errorf("at least one matcher (traces, metrics, logs, trace_structure, expect, golden, semconv, cardinality, correlations, or batches) must be specified")
 	}
 	return nil
 }
 
-// applyFilters applies filter predicates to determine if a contract should be validated
-func (c *Contract) applyFilters(data OpenTelemetryData) bool {
-	if len(c.Filters) == 0 {
+// hasMatchers reports whether the contract asserts anything about its output.
+// Per-input expectations count as matchers.
+func (c *Contract) hasMatchers() bool {
+	if len(c.Matchers.Traces) > 0 || len(c.Matchers.Metrics) > 0 || len(c.Matchers.Logs) > 0 ||
+		c.Matchers.TraceStructure != nil || c.Matchers.Expect != nil || c.Matchers.Golden != nil ||
+		c.Matchers.Semconv != nil || len(c.Matchers.Cardinality) > 0 || len(c.Matchers.Correlations) > 0 ||
+		c.Matchers.Batches != nil {
 		return true
 	}
+	for _, signal := range []SignalType{SignalTypeTraces, SignalTypeMetrics, SignalTypeLogs} {
+		for _, mode := range c.InputExpectations(signal) {
+			if mode != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// InputExpectations returns the expected outcome of each input item of a signal, in
+// input order. An item's own expect overrides the signal default; items without
+// either have an empty mode.
+func (c *Contract) InputExpectations(signal SignalType) []ExpectMode {
+	var defaults Expectations
+	if c.Matchers.Expect != nil {
+		defaults = *c.Matchers.Expect
+	}
+
+	var modes []ExpectMode
+	switch signal {
+	case SignalTypeTraces:
+		for _, input := range c.Inputs.Traces {
+			modes = append(modes, expectOrDefault(input.Expect, defaults.Traces))
+		}
+	case SignalTypeMetrics:
+		for _, input := range c.Inputs.Metrics {
+			modes = append(modes, expectOrDefault(input.Expect, defaults.Metrics))
+		}
+	case SignalTypeLogs:
+		for _, input := range c.Inputs.Logs {
+			modes = append(modes, expectOrDefault(input.Expect, defaults.Logs))
+		}
+	}
+	return modes
+}
 
-	for _, filter := range c.Filters {
-		if !c.evaluateFilter(filter, data) {
+// expectsNoOutput reports whether every input item of a signal is expected to be dropped
+func (c *Contract) expectsNoOutput(signal SignalType) bool {
+	modes := c.InputExpectations(signal)
+	for _, mode := range modes {
+		if mode != ExpectDropped {
 			return false
 		}
 	}
-	return true
+	return len(modes) > 0
 }
 
-// evaluateFilter evaluates a single filter against the data
-func (c *Contract) evaluateFilter(filter Filter, data OpenTelemetryData) bool {
-	// This is a simplified implementation - in practice, this would use the matcher package
-	// For now, we'll return true to indicate the filter passes
-	// TODO: Implement actual filter evaluation logic
-	return true
+// expectOrDefault returns mode, or fallback when mode is unset
+func expectOrDefault(mode, fallback ExpectMode) ExpectMode {
+	if mode != "" {
+		return mode
+	}
+	return fallback
+}
+
+// applyFilters applies filter predicates to determine if a contract should be validated
+func (c *Contract) applyFilters(data OpenTelemetryData) (bool, string, error) {
+	if len(c.Filters) == 0 {
+		return true, "", nil
+	}
+	return ApplyFilters(c.Filters, data)
 }
 
 // validateInputData validates that the input data matches the contract's input specification
@@ -484,10 +842,11 @@ func (c *Contract) validateInputData(data OpenTelemetryData) error {
 	return nil
 }
 
-// validateOutputData validates that the output data matches the contract's matchers
+// validateOutputData validates that the output data matches the contract's matchers.
+// Empty output is allowed for a signal whose inputs are all expected to be dropped.
 func (c *Contract) validateOutputData(data OpenTelemetryData) error {
 	// Validate traces output
-	if len(c.Matchers.Traces) > 0 {
+	if (len(c.Matchers.Traces) > 0 || c.Matchers.TraceStructure != nil) && !c.expectsNoOutput(SignalTypeTraces) {
 		resourceSpans := data.Traces.ResourceSpans()
 		if resourceSpans.Len() == 0 {
 			return fmt.Errorf("contract expects trace output but no traces found")
@@ -495,7 +854,7 @@ func (c *Contract) validateOutputData(data OpenTelemetryData) error {
 	}
 
 	// Validate metrics output
-	if len(c.Matchers.Metrics) > 0 {
+	if len(c.Matchers.Metrics) > 0 && !c.expectsNoOutput(SignalTypeMetrics) {
 		resourceMetrics := data.Metrics.ResourceMetrics()
 		if resourceMetrics.Len() == 0 {
 			return fmt.Errorf("contract expects metric output but no metrics found")
@@ -503,7 +862,7 @@ func (c *Contract) validateOutputData(data OpenTelemetryData) error {
 	}
 
 	// Validate logs output
-	if len(c.Matchers.Logs) > 0 {
+	if len(c.Matchers.Logs) > 0 && !c.expectsNoOutput(SignalTypeLogs) {
 		resourceLogs := data.Logs.ResourceLogs()
 		if resourceLogs.Len() == 0 {
 			return fmt.Errorf("contract expects log output but no logs found")
@@ -555,6 +914,21 @@ func (c *Contract) GetVersion() string {
 	return c.Version
 }
 
+// GetTimeout returns the contract's timeout override, or 0 when it uses the runner's
+func (c *Contract) GetTimeout() (time.Duration, error) {
+	if c.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(c.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", c.Timeout, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout %s must be positive", c.Timeout)
+	}
+	return timeout, nil
+}
+
 func (c *Contract) GetFilters() []Filter {
 	return c.Filters
 }
@@ -574,6 +948,8 @@ func ValueToInterface(value pcommon.Value) interface{} {
 		return value.Double()
 	case pcommon.ValueTypeBool:
 		return value.Bool()
+	case pcommon.ValueTypeBytes:
+		return value.Bytes().AsRaw()
 	case pcommon.ValueTypeMap:
 		result := make(map[string]interface{})
 		value.Map().Range(func(k string, v pcommon.Value) bool {
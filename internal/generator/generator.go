@@ -48,14 +48,17 @@ func (g *Generator) GenerateFromContract(contractDef *contract.Contract) contrac
 		data.Traces = g.generateTraces(contractDef.Inputs.Traces)
 	}
 
+	// Logs and exemplars reference input spans by name
+	spans := spansByName(data.Traces)
+
 	// Generate metrics
 	if len(contractDef.Inputs.Metrics) > 0 {
-		data.Metrics = g.generateMetrics(contractDef.Inputs.Metrics)
+		data.Metrics = g.generateMetrics(contractDef.Inputs.Metrics, spans)
 	}
 
 	// Generate logs
 	if len(contractDef.Inputs.Logs) > 0 {
-		data.Logs = g.generateLogs(contractDef.Inputs.Logs)
+		data.Logs = g.generateLogs(contractDef.Inputs.Logs, spans)
 	}
 
 	return data
@@ -82,6 +85,8 @@ func (g *Generator) GenerateRealistic(signalType contract.SignalType, count int)
 // generateTraces generates trace data from contract inputs
 func (g *Generator) generateTraces(inputs []contract.TraceInput) ptrace.Traces {
 	traces := ptrace.NewTraces()
+	spans := make([]ptrace.Span, 0, len(inputs))
+	indexes := make(map[string]int)
 
 	for _, input := range inputs {
 		resourceSpans := traces.ResourceSpans().AppendEmpty()
@@ -120,13 +125,65 @@ func (g *Generator) generateTraces(inputs []contract.TraceInput) ptrace.Traces {
 		for key, value := range input.Attributes {
 			g.setAttribute(span.Attributes(), key, value)
 		}
+
+		// Add events
+		for _, eventInput := range input.Events {
+			event := span.Events().AppendEmpty()
+			event.SetName(eventInput.Name)
+			event.SetTimestamp(pcommon.NewTimestampFromTime(g.baseTime.Add(50 * time.Millisecond)))
+			for key, value := range eventInput.Attributes {
+				g.setAttribute(event.Attributes(), key, value)
+			}
+		}
+
+		if _, exists := indexes[input.SpanName]; !exists {
+			indexes[input.SpanName] = len(spans)
+		}
+		spans = append(spans, span)
+	}
+
+	// Attach spans to named input parents, sharing the root ancestor's trace ID
+	resolved := make(map[int]bool)
+	var resolveParent func(i int, visiting map[int]bool)
+	resolveParent = func(i int, visiting map[int]bool) {
+		if resolved[i] || visiting[i] {
+			return
+		}
+		visiting[i] = true
+		if parentIndex, ok := indexes[inputs[i].ParentSpan]; ok && parentIndex != i {
+			resolveParent(parentIndex, visiting)
+			parent := spans[parentIndex]
+			spans[i].SetTraceID(parent.TraceID())
+			spans[i].SetParentSpanID(parent.SpanID())
+		}
+		resolved[i] = true
+	}
+	for i := range inputs {
+		resolveParent(i, make(map[int]bool))
+	}
+
+	// Add links once every named span has its IDs
+	for i, input := range inputs {
+		for _, linkInput := range input.Links {
+			link := spans[i].Links().AppendEmpty()
+			if targetIndex, ok := indexes[linkInput.Span]; ok {
+				link.SetTraceID(spans[targetIndex].TraceID())
+				link.SetSpanID(spans[targetIndex].SpanID())
+			} else {
+				link.SetTraceID(g.generateTraceID())
+				link.SetSpanID(g.generateSpanID())
+			}
+			for key, value := range linkInput.Attributes {
+				g.setAttribute(link.Attributes(), key, value)
+			}
+		}
 	}
 
 	return traces
 }
 
 // generateMetrics generates metric data from contract inputs
-func (g *Generator) generateMetrics(inputs []contract.MetricInput) pmetric.Metrics {
+func (g *Generator) generateMetrics(inputs []contract.MetricInput, spans map[string]ptrace.Span) pmetric.Metrics {
 	metrics := pmetric.NewMetrics()
 
 	for _, input := range inputs {
@@ -173,6 +230,7 @@ func (g *Generator) generateMetrics(inputs []contract.MetricInput) pmetric.Metri
 			}
 			// Set timestamp for histogram
 			histogramDataPoint.SetTimestamp(pcommon.NewTimestampFromTime(g.baseTime))
+			g.addExemplars(histogramDataPoint.Exemplars(), input, spans)
 			continue
 		}
 
@@ -183,13 +241,57 @@ func (g *Generator) generateMetrics(inputs []contract.MetricInput) pmetric.Metri
 
 		// Set timestamp
 		dataPoint.SetTimestamp(pcommon.NewTimestampFromTime(g.baseTime))
+		g.addExemplars(dataPoint.Exemplars(), input, spans)
 	}
 
 	return metrics
 }
 
+// addExemplars records an exemplar for each named input span of a metric input
+func (g *Generator) addExemplars(exemplars pmetric.ExemplarSlice, input contract.MetricInput, spans map[string]ptrace.Span) {
+	for _, exemplarInput := range input.Exemplars {
+		exemplar := exemplars.AppendEmpty()
+		exemplar.SetTimestamp(pcommon.NewTimestampFromTime(g.baseTime))
+		value := exemplarInput.Value
+		if value == nil {
+			value = input.Value
+		}
+		point := pmetric.NewNumberDataPoint()
+		g.setMetricValue(point, value)
+		if point.ValueType() == pmetric.NumberDataPointValueTypeInt {
+			exemplar.SetIntValue(point.IntValue())
+		} else {
+			exemplar.SetDoubleValue(point.DoubleValue())
+		}
+		if span, ok := spans[exemplarInput.Span]; ok {
+			exemplar.SetTraceID(span.TraceID())
+			exemplar.SetSpanID(span.SpanID())
+		} else {
+			exemplar.SetTraceID(g.generateTraceID())
+			exemplar.SetSpanID(g.generateSpanID())
+		}
+	}
+}
+
+// spansByName indexes generated spans by name, keeping the first span of each name
+func spansByName(traces ptrace.Traces) map[string]ptrace.Span {
+	spans := make(map[string]ptrace.Span)
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		scopeSpans := traces.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < scopeSpans.Len(); j++ {
+			for k := 0; k < scopeSpans.At(j).Spans().Len(); k++ {
+				span := scopeSpans.At(j).Spans().At(k)
+				if _, exists := spans[span.Name()]; !exists {
+					spans[span.Name()] = span
+				}
+			}
+		}
+	}
+	return spans
+}
+
 // generateLogs generates log data from contract inputs
-func (g *Generator) generateLogs(inputs []contract.LogInput) plog.Logs {
+func (g *Generator) generateLogs(inputs []contract.LogInput, spans map[string]ptrace.Span) plog.Logs {
 	logs := plog.NewLogs()
 
 	for _, input := range inputs {
@@ -215,11 +317,14 @@ func (g *Generator) generateLogs(inputs []contract.LogInput) plog.Logs {
 		// Set timestamp
 		logRecord.SetTimestamp(pcommon.NewTimestampFromTime(g.baseTime))
 
-		// Set trace context if available
-		traceID := g.generateTraceID()
-		spanID := g.generateSpanID()
-		logRecord.SetTraceID(traceID)
-		logRecord.SetSpanID(spanID)
+		// Set trace context, from the named input span if any
+		if span, ok := spans[input.Span]; ok {
+			logRecord.SetTraceID(span.TraceID())
+			logRecord.SetSpanID(span.SpanID())
+		} else {
+			logRecord.SetTraceID(g.generateTraceID())
+			logRecord.SetSpanID(g.generateSpanID())
+		}
 	}
 
 	return logs
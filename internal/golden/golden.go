@@ -0,0 +1,300 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// maskedValue replaces the value of every masked field
+const maskedValue = "<masked>"
+
+// ErrMissing is returned when a contract references a snapshot that does not exist yet
+var ErrMissing = errors.New("golden snapshot does not exist")
+
+// Path resolves a contract's snapshot file relative to the directory of the contract file.
+// A contract bound to a pipeline through its selectors runs once per matched pipeline, so
+// each pipeline gets its own snapshot, such as golden/cart.traces_auth.json.
+func Path(contractDef *contract.Contract) string {
+	file := contractDef.Matchers.Golden.File
+	if contractDef.HasPipelineSelectors() && contractDef.Pipeline != "" {
+		ext := filepath.Ext(file)
+		file = strings.TrimSuffix(file, ext) + "." + strings.ReplaceAll(contractDef.Pipeline, "/", "_") + ext
+	}
+	if filepath.IsAbs(file) || contractDef.FilePath == "" {
+		return file
+	}
+	return filepath.Join(filepath.Dir(contractDef.FilePath), file)
+}
+
+// Normalize renders output as a single OTLP JSON document holding the resourceSpans,
+// resourceMetrics and resourceLogs of every non-empty signal. Attributes are sorted by
+// key and volatile fields are masked, so equal telemetry always renders identically.
+func Normalize(data contract.OpenTelemetryData, mask contract.GoldenMask) ([]byte, error) {
+	document := make(map[string]interface{})
+
+	if data.Traces.ResourceSpans().Len() > 0 {
+		encoded, err := (&ptrace.JSONMarshaler{}).MarshalTraces(data.Traces)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal traces: %w", err)
+		}
+		if err := mergeJSON(document, encoded); err != nil {
+			return nil, err
+		}
+	}
+	if data.Metrics.ResourceMetrics().Len() > 0 {
+		encoded, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(data.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metrics: %w", err)
+		}
+		if err := mergeJSON(document, encoded); err != nil {
+			return nil, err
+		}
+	}
+	if data.Logs.ResourceLogs().Len() > 0 {
+		encoded, err := (&plog.JSONMarshaler{}).MarshalLogs(data.Logs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal logs: %w", err)
+		}
+		if err := mergeJSON(document, encoded); err != nil {
+			return nil, err
+		}
+	}
+
+	return render(document, mask)
+}
+
+// NormalizeJSON applies the same normalisation to an OTLP JSON document read from disk,
+// so hand-written or exported snapshots compare equal to rendered output
+func NormalizeJSON(encoded []byte, mask contract.GoldenMask) ([]byte, error) {
+	document := make(map[string]interface{})
+	if err := mergeJSON(document, encoded); err != nil {
+		return nil, err
+	}
+	return render(document, mask)
+}
+
+// Compare checks rendered output against the snapshot at path
+func Compare(path string, actual []byte, mask contract.GoldenMask) error {
+	encoded, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s (run with --update-golden to create it)", ErrMissing, path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read golden snapshot %s: %w", path, err)
+	}
+
+	expected, err := NormalizeJSON(encoded, mask)
+	if err != nil {
+		return fmt.Errorf("failed to parse golden snapshot %s: %w", path, err)
+	}
+	if bytes.Equal(expected, actual) {
+		return nil
+	}
+	return fmt.Errorf("output does not match golden snapshot %s: %s", path, firstDifference(expected, actual))
+}
+
+// Write stores rendered output as the snapshot at path, creating parent directories
+func Write(path string, actual []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create golden snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(path, actual, 0644); err != nil {
+		return fmt.Errorf("failed to write golden snapshot %s: %w", path, err)
+	}
+	return nil
+}
+
+// mergeJSON decodes an OTLP JSON document into document, keeping numbers verbatim
+func mergeJSON(document map[string]interface{}, encoded []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return fmt.Errorf("failed to decode OTLP JSON: %w", err)
+	}
+	for key, value := range fields {
+		document[key] = value
+	}
+	return nil
+}
+
+// render masks and sorts a decoded document and encodes it as indented JSON
+func render(document map[string]interface{}, mask contract.GoldenMask) ([]byte, error) {
+	n := newNormalizer(mask)
+	normalized := n.walk(document)
+
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(normalized); err != nil {
+		return nil, fmt.Errorf("failed to encode golden snapshot: %w", err)
+	}
+	return encoded.Bytes(), nil
+}
+
+// normalizer masks volatile fields while walking a decoded OTLP JSON document
+type normalizer struct {
+	mask       contract.GoldenMask
+	attributes map[string]bool
+	fields     map[string]bool
+	traceIDs   map[string]string
+	spanIDs    map[string]string
+}
+
+// newNormalizer creates a normalizer for the given mask rules
+func newNormalizer(mask contract.GoldenMask) *normalizer {
+	n := &normalizer{
+		mask:       mask,
+		attributes: make(map[string]bool),
+		fields:     make(map[string]bool),
+		traceIDs:   make(map[string]string),
+		spanIDs:    make(map[string]string),
+	}
+	for _, key := range mask.Attributes {
+		n.attributes[key] = true
+	}
+	for _, field := range mask.Fields {
+		n.fields[field] = true
+	}
+	return n
+}
+
+// walk returns a normalised copy of node. Object keys are visited in sorted order so
+// ID placeholders are numbered deterministically by first appearance.
+func (n *normalizer) walk(node interface{}) interface{} {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		normalized := make(map[string]interface{}, len(value))
+		for _, key := range keys {
+			normalized[key] = n.field(key, value[key])
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(value))
+		for i, element := range value {
+			normalized[i] = n.walk(element)
+		}
+		return normalized
+	default:
+		return node
+	}
+}
+
+// field normalises the value of a single object field
+func (n *normalizer) field(key string, value interface{}) interface{} {
+	if n.fields[key] {
+		return maskedValue
+	}
+
+	switch {
+	case key == "attributes":
+		return n.attributeList(value)
+	case n.mask.MaskIDs() && key == "traceId":
+		return placeholder(n.traceIDs, "trace", value)
+	case n.mask.MaskIDs() && (key == "spanId" || key == "parentSpanId"):
+		return placeholder(n.spanIDs, "span", value)
+	case n.mask.MaskTimestamps() && strings.HasSuffix(strings.ToLower(key), "timeunixnano"):
+		return maskedValue
+	}
+	return n.walk(value)
+}
+
+// attributeList sorts an OTLP key/value list by key and masks the configured attributes
+func (n *normalizer) attributeList(value interface{}) interface{} {
+	list, ok := value.([]interface{})
+	if !ok {
+		return n.walk(value)
+	}
+
+	normalized := make([]interface{}, 0, len(list))
+	for _, element := range list {
+		attribute, ok := element.(map[string]interface{})
+		if ok {
+			if key, _ := attribute["key"].(string); n.attributes[key] {
+				normalized = append(normalized, map[string]interface{}{
+					"key":   key,
+					"value": map[string]interface{}{"stringValue": maskedValue},
+				})
+				continue
+			}
+		}
+		normalized = append(normalized, n.walk(element))
+	}
+
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return attributeKey(normalized[i]) < attributeKey(normalized[j])
+	})
+	return normalized
+}
+
+// attributeKey returns the key of an OTLP key/value entry
+func attributeKey(element interface{}) string {
+	if attribute, ok := element.(map[string]interface{}); ok {
+		key, _ := attribute["key"].(string)
+		return key
+	}
+	return ""
+}
+
+// placeholder replaces an ID with a stable name numbered by first appearance, keeping
+// parent and link references intact while removing the random ID itself
+func placeholder(seen map[string]string, kind string, value interface{}) interface{} {
+	id, ok := value.(string)
+	if !ok || id == "" {
+		return value
+	}
+	if name, ok := seen[id]; ok {
+		return name
+	}
+	name := fmt.Sprintf("<%s-%d>", kind, len(seen)+1)
+	seen[id] = name
+	return name
+}
+
+// firstDifference describes the first line where two rendered documents differ
+func firstDifference(expected, actual []byte) string {
+	expectedLines := strings.Split(string(expected), "\n")
+	actualLines := strings.Split(string(actual), "\n")
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var want, got string
+		if i < len(expectedLines) {
+			want = strings.TrimSpace(expectedLines[i])
+		}
+		if i < len(actualLines) {
+			got = strings.TrimSpace(actualLines[i])
+		}
+		if want != got {
+			return fmt.Sprintf("first difference at line %d: expected %s, got %s", i+1, describeLine(want), describeLine(got))
+		}
+	}
+	return "documents differ"
+}
+
+// describeLine formats a snapshot line for messages
+func describeLine(line string) string {
+	if line == "" {
+		return "end of document"
+	}
+	return "`" + line + "`"
+}
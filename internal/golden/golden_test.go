@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package golden
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// newOutput builds a parent and child span with the given IDs and request ID
+func newOutput(traceID, requestID byte) contract.OpenTelemetryData {
+	data := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	spans := data.Traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+
+	parent := spans.AppendEmpty()
+	parent.SetName("GET /cart")
+	parent.SetTraceID(pcommon.TraceID([16]byte{traceID}))
+	parent.SetSpanID(pcommon.SpanID([8]byte{traceID, 1}))
+	parent.SetStartTimestamp(pcommon.Timestamp(uint64(traceID) * 1000))
+	parent.Attributes().PutStr("request.id", string(rune('a'+requestID)))
+	parent.Attributes().PutStr("http.route", "/cart")
+
+	child := spans.AppendEmpty()
+	child.SetName("load cart")
+	child.SetTraceID(pcommon.TraceID([16]byte{traceID}))
+	child.SetSpanID(pcommon.SpanID([8]byte{traceID, 2}))
+	child.SetParentSpanID(parent.SpanID())
+	return data
+}
+
+func TestNormalize_MasksVolatileFields(t *testing.T) {
+	mask := contract.GoldenMask{Attributes: []string{"request.id"}}
+
+	first, err := Normalize(newOutput(1, 1), mask)
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	second, err := Normalize(newOutput(2, 2), mask)
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("Expected masked output to be identical, got:\n%s\nand:\n%s", first, second)
+	}
+
+	rendered := string(first)
+	for _, want := range []string{`"traceId": "<trace-1>"`, `"parentSpanId": "<span-1>"`, `"startTimeUnixNano": "<masked>"`} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected rendered output to contain %s, got:\n%s", want, rendered)
+		}
+	}
+	if strings.Index(rendered, `"http.route"`) > strings.Index(rendered, `"request.id"`) {
+		t.Error("Expected attributes to be sorted by key")
+	}
+
+	// Disabling a mask keeps the field verbatim
+	ids := false
+	unmasked, err := Normalize(newOutput(1, 1), contract.GoldenMask{IDs: &ids})
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	if !strings.Contains(string(unmasked), `"traceId": "01000000000000000000000000000000"`) {
+		t.Errorf("Expected trace ID to be kept, got:\n%s", unmasked)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	mask := contract.GoldenMask{}
+	path := filepath.Join(t.TempDir(), "snapshots", "cart.json")
+	actual, err := Normalize(newOutput(1, 1), mask)
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+
+	if err := Compare(path, actual, mask); !errors.Is(err, ErrMissing) {
+		t.Errorf("Expected missing snapshot error, got: %v", err)
+	}
+
+	if err := Write(path, actual); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := Compare(path, actual, mask); err != nil {
+		t.Errorf("Expected output to match written snapshot, got: %v", err)
+	}
+
+	changed := newOutput(1, 1)
+	changed.Traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).SetName("fetch cart")
+	actual, err = Normalize(changed, mask)
+	if err != nil {
+		t.Fatalf("Normalize failed: %v", err)
+	}
+	err = Compare(path, actual, mask)
+	if err == nil || !strings.Contains(err.Error(), "expected `\"name\": \"load cart\",`, got `\"name\": \"fetch cart\",`") {
+		t.Errorf("Expected name difference, got: %v", err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	contractDef := &contract.Contract{
+		FilePath: filepath.Join("contracts", "cart.yaml"),
+		Matchers: contract.Matchers{Golden: &contract.GoldenMatcher{File: "golden/cart.json"}},
+	}
+	if got, want := Path(contractDef), filepath.Join("contracts", "golden", "cart.json"); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	// Copies bound to each pipeline matched by selectors keep separate snapshots
+	contractDef.PipelineSelectors = &contract.PipelineSelectors{
+		Selectors: []contract.PipelineSelector{{Field: "type", Operator: contract.PipelineSelectorOperatorEquals, Value: "trace"}},
+	}
+	contractDef.Pipeline = "traces/auth"
+	if got, want := Path(contractDef), filepath.Join("contracts", "golden", "cart.traces_auth.json"); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	contractDef.Pipeline = "traces/billing"
+	if got, want := Path(contractDef), filepath.Join("contracts", "golden", "cart.traces_billing.json"); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
@@ -3,9 +3,12 @@
 
 package harness
 
+import "context"
+
 // CollectorService defines the interface for a collector service
 type CollectorService interface {
 	Start() error
 	Stop() error
-	ProcessData(input interface{}) (interface{}, error)
+	SetPipeline(name string)
+	ProcessData(ctx context.Context, input interface{}) (interface{}, error)
 }
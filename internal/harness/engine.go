@@ -0,0 +1,363 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// TracesProcessor is a processor component that transforms traces
+type TracesProcessor interface {
+	ProcessTraces(ctx context.Context, traces ptrace.Traces) error
+}
+
+// MetricsProcessor is a processor component that transforms metrics
+type MetricsProcessor interface {
+	ProcessMetrics(ctx context.Context, metrics pmetric.Metrics) error
+}
+
+// LogsProcessor is a processor component that transforms logs
+type LogsProcessor interface {
+	ProcessLogs(ctx context.Context, logs plog.Logs) error
+}
+
+// TracesBatcher is a processor component that regroups traces into new batches,
+// leaving its input unchanged
+type TracesBatcher interface {
+	BatchTraces(ctx context.Context, traces ptrace.Traces) ([]ptrace.Traces, error)
+}
+
+// MetricsBatcher is a processor component that regroups metrics into new batches,
+// leaving its input unchanged
+type MetricsBatcher interface {
+	BatchMetrics(ctx context.Context, metrics pmetric.Metrics) ([]pmetric.Metrics, error)
+}
+
+// LogsBatcher is a processor component that regroups logs into new batches,
+// leaving its input unchanged
+type LogsBatcher interface {
+	BatchLogs(ctx context.Context, logs plog.Logs) ([]plog.Logs, error)
+}
+
+// ProcessorFactory creates the processor component for a configured processor ID
+type ProcessorFactory func(id ComponentID, config interface{}) (component.Component, error)
+
+// BuildProcessors creates a processor component for every configured processor, keyed by ID
+func BuildProcessors(config CollectorConfig, factory ProcessorFactory) (map[string]component.Component, error) {
+	processors := make(map[string]component.Component, len(config.Processors))
+	for name, processorConfig := range config.Processors {
+		id, err := ParseComponentID(name)
+		if err != nil {
+			return nil, fmt.Errorf("processor %s: %w", name, err)
+		}
+		processor, err := factory(id, processorConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create processor %s: %w", name, err)
+		}
+		processors[name] = processor
+	}
+	return processors, nil
+}
+
+// Engine runs telemetry through the processor chains of a collector configuration.
+// Each processor is a consumer that works on its own copy of a batch and passes the
+// result to the next consumer, so no stage mutates the data it was given.
+type Engine struct {
+	config     CollectorConfig
+	processors map[string]component.Component
+	logger     *zap.Logger
+}
+
+// NewEngine creates an engine over processor components built from the configuration
+func NewEngine(config CollectorConfig, processors map[string]component.Component, logger *zap.Logger) *Engine {
+	return &Engine{
+		config:     config,
+		processors: processors,
+		logger:     logger,
+	}
+}
+
+// Start starts every processor component
+func (e *Engine) Start(ctx context.Context) error {
+	for name, processor := range e.processors {
+		if err := processor.Start(ctx, nil); err != nil {
+			return fmt.Errorf("failed to start processor %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Shutdown stops every processor component
+func (e *Engine) Shutdown(ctx context.Context) error {
+	for name, processor := range e.processors {
+		if err := processor.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to stop processor %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Process runs each signal of the input through its own pipeline and returns what
+// reaches the end of the chains. The input is left unchanged.
+func (e *Engine) Process(ctx context.Context, pipeline string, input contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
+	sink := NewMockConsumer()
+
+	if input.Traces.ResourceSpans().Len() > 0 {
+		traces, err := e.Traces(pipeline, sink)
+		if err != nil {
+			return contract.OpenTelemetryData{}, err
+		}
+		if err := traces.ConsumeTraces(ctx, input.Traces); err != nil {
+			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process traces: %w", err)
+		}
+	}
+
+	if input.Metrics.ResourceMetrics().Len() > 0 {
+		metrics, err := e.Metrics(pipeline, sink)
+		if err != nil {
+			return contract.OpenTelemetryData{}, err
+		}
+		if err := metrics.ConsumeMetrics(ctx, input.Metrics); err != nil {
+			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process metrics: %w", err)
+		}
+	}
+
+	if input.Logs.ResourceLogs().Len() > 0 {
+		logs, err := e.Logs(pipeline, sink)
+		if err != nil {
+			return contract.OpenTelemetryData{}, err
+		}
+		if err := logs.ConsumeLogs(ctx, input.Logs); err != nil {
+			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process logs: %w", err)
+		}
+	}
+
+	return mergeBatches(sink), nil
+}
+
+// mergeBatches combines every batch delivered to the sink into one output, recording
+// the batch boundaries so matchers can assert on them
+func mergeBatches(sink *MockConsumer) contract.OpenTelemetryData {
+	output := contract.OpenTelemetryData{
+		Time:    time.Now(),
+		Traces:  ptrace.NewTraces(),
+		Metrics: pmetric.NewMetrics(),
+		Logs:    plog.NewLogs(),
+	}
+	for _, traces := range sink.GetTraces() {
+		for i := 0; i < traces.ResourceSpans().Len(); i++ {
+			traces.ResourceSpans().At(i).CopyTo(output.Traces.ResourceSpans().AppendEmpty())
+		}
+		output.Batches = append(output.Batches, contract.Batch{Signal: contract.SignalTypeTraces, Items: traces.SpanCount()})
+	}
+	for _, metrics := range sink.GetMetrics() {
+		for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+			metrics.ResourceMetrics().At(i).CopyTo(output.Metrics.ResourceMetrics().AppendEmpty())
+		}
+		output.Batches = append(output.Batches, contract.Batch{Signal: contract.SignalTypeMetrics, Items: metrics.DataPointCount()})
+	}
+	for _, logs := range sink.GetLogs() {
+		for i := 0; i < logs.ResourceLogs().Len(); i++ {
+			logs.ResourceLogs().At(i).CopyTo(output.Logs.ResourceLogs().AppendEmpty())
+		}
+		output.Batches = append(output.Batches, contract.Batch{Signal: contract.SignalTypeLogs, Items: logs.LogRecordCount()})
+	}
+	return output
+}
+
+// Traces returns the first consumer of the traces pipeline, which ends at next
+func (e *Engine) Traces(pipeline string, next consumer.Traces) (consumer.Traces, error) {
+	chain, err := e.chain(SignalTraces, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		stage, err := tracesStage(chain[i], next)
+		if err != nil {
+			return nil, err
+		}
+		next = stage
+	}
+	return next, nil
+}
+
+// Metrics returns the first consumer of the metrics pipeline, which ends at next
+func (e *Engine) Metrics(pipeline string, next consumer.Metrics) (consumer.Metrics, error) {
+	chain, err := e.chain(SignalMetrics, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		stage, err := metricsStage(chain[i], next)
+		if err != nil {
+			return nil, err
+		}
+		next = stage
+	}
+	return next, nil
+}
+
+// Logs returns the first consumer of the logs pipeline, which ends at next
+func (e *Engine) Logs(pipeline string, next consumer.Logs) (consumer.Logs, error) {
+	chain, err := e.chain(SignalLogs, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		stage, err := logsStage(chain[i], next)
+		if err != nil {
+			return nil, err
+		}
+		next = stage
+	}
+	return next, nil
+}
+
+// chainedProcessor is a processor component at its place in a pipeline
+type chainedProcessor struct {
+	id        ComponentID
+	processor component.Component
+}
+
+// chain resolves the processor chain of a signal to its components, in order
+func (e *Engine) chain(signal, pipeline string) ([]chainedProcessor, error) {
+	ids, err := e.config.ProcessorChain(signal, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s pipeline: %w", signal, err)
+	}
+	chain := make([]chainedProcessor, 0, len(ids))
+	for _, id := range ids {
+		processor, ok := e.processors[id.String()]
+		if !ok {
+			return nil, fmt.Errorf("processor %s is not initialized", id)
+		}
+		chain = append(chain, chainedProcessor{id: id, processor: processor})
+	}
+	e.logger.Debug("Resolved processor chain",
+		zap.String("signal", signal),
+		zap.String("pipeline", pipeline),
+		zap.Int("processor_count", len(chain)))
+	return chain, nil
+}
+
+// tracesStage returns the consumer that runs traces through one processor and passes each
+// resulting batch to next; processors that do not handle traces pass them through
+func tracesStage(link chainedProcessor, next consumer.Traces) (consumer.Traces, error) {
+	switch processor := link.processor.(type) {
+	case TracesBatcher:
+		return consumer.NewTraces(func(ctx context.Context, traces ptrace.Traces) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			batches, err := processor.BatchTraces(ctx, traces)
+			if err != nil {
+				return fmt.Errorf("processor %s: %w", link.id, err)
+			}
+			for _, batch := range batches {
+				if err := next.ConsumeTraces(ctx, batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case TracesProcessor:
+		return consumer.NewTraces(func(ctx context.Context, traces ptrace.Traces) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			processed := ptrace.NewTraces()
+			traces.CopyTo(processed)
+			if err := processor.ProcessTraces(ctx, processed); err != nil {
+				return fmt.Errorf("processor %s: %w", link.id, err)
+			}
+			return next.ConsumeTraces(ctx, processed)
+		})
+	default:
+		return next, nil
+	}
+}
+
+// metricsStage returns the consumer that runs metrics through one processor and passes each
+// resulting batch to next; processors that do not handle metrics pass them through
+func metricsStage(link chainedProcessor, next consumer.Metrics) (consumer.Metrics, error) {
+	switch processor := link.processor.(type) {
+	case MetricsBatcher:
+		return consumer.NewMetrics(func(ctx context.Context, metrics pmetric.Metrics) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			batches, err := processor.BatchMetrics(ctx, metrics)
+			if err != nil {
+				return fmt.Errorf("processor %s: %w", link.id, err)
+			}
+			for _, batch := range batches {
+				if err := next.ConsumeMetrics(ctx, batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case MetricsProcessor:
+		return consumer.NewMetrics(func(ctx context.Context, metrics pmetric.Metrics) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			processed := pmetric.NewMetrics()
+			metrics.CopyTo(processed)
+			if err := processor.ProcessMetrics(ctx, processed); err != nil {
+				return fmt.Errorf("processor %s: %w", link.id, err)
+			}
+			return next.ConsumeMetrics(ctx, processed)
+		})
+	default:
+		return next, nil
+	}
+}
+
+// logsStage returns the consumer that runs logs through one processor and passes each
+// resulting batch to next; processors that do not handle logs pass them through
+func logsStage(link chainedProcessor, next consumer.Logs) (consumer.Logs, error) {
+	switch processor := link.processor.(type) {
+	case LogsBatcher:
+		return consumer.NewLogs(func(ctx context.Context, logs plog.Logs) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			batches, err := processor.BatchLogs(ctx, logs)
+			if err != nil {
+				return fmt.Errorf("processor %s: %w", link.id, err)
+			}
+			for _, batch := range batches {
+				if err := next.ConsumeLogs(ctx, batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case LogsProcessor:
+		return consumer.NewLogs(func(ctx context.Context, logs plog.Logs) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			processed := plog.NewLogs()
+			logs.CopyTo(processed)
+			if err := processor.ProcessLogs(ctx, processed); err != nil {
+				return fmt.Errorf("processor %s: %w", link.id, err)
+			}
+			return next.ConsumeLogs(ctx, processed)
+		})
+	default:
+		return next, nil
+	}
+}
@@ -5,11 +5,15 @@ package harness
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/goedelsoup/waveform/internal/generator"
+	"github.com/goedelsoup/waveform/internal/golden"
 	"github.com/goedelsoup/waveform/internal/matcher"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -30,20 +34,30 @@ const (
 type TestResult struct {
 	Contract   *contract.Contract
 	Valid      bool
+	Skipped    bool
+	SkipReason string
 	Errors     []string
+	Failures   []contract.ValidationError // Individual matcher failures behind Errors
 	Warnings   []string
+	Diffs      []*contract.TelemetryDiff // Expected shape against the closest actual item for failed matchers
 	Duration   time.Duration
 	InputData  contract.OpenTelemetryData
 	OutputData contract.OpenTelemetryData
+	Attempts   int  // Runs of the contract, counting retries
+	Flaky      bool // Passed only after a retry
+	TimedOut   bool // A stage ran past the contract's timeout
 }
 
 // TestResults represents the results of all tests
 type TestResults struct {
-	Results     []TestResult
-	TotalTests  int
-	PassedTests int
-	FailedTests int
-	Duration    time.Duration
+	Results      []TestResult
+	TotalTests   int
+	PassedTests  int
+	FailedTests  int
+	SkippedTests int
+	FlakyTests   int
+	NotRunTests  int // Contracts left unscheduled after fail-fast stopped the run
+	Duration     time.Duration
 }
 
 // CollectorConfig represents the configuration for a collector
@@ -62,6 +76,15 @@ type TestHarness struct {
 	matcher          *matcher.Matcher
 	logger           *zap.Logger
 	collectorService CollectorService
+	serviceFactory   func() CollectorService
+	processorFactory ProcessorFactory
+	updateGolden     bool
+	parallel         int
+	timeout          time.Duration
+	failFast         bool
+	retry            RetryPolicy
+	pipelineEnricher PipelineEnricher
+	unmatched        map[*contract.Contract]string // Contracts whose pipeline selectors matched no pipeline
 }
 
 // NewTestHarness creates a new test harness
@@ -86,6 +109,44 @@ func (h *TestHarness) SetCollectorService(service CollectorService) {
 	h.collectorService = service
 }
 
+// SetCollectorServiceFactory sets the factory that creates a collector service for each
+// parallel worker, so workers never share component instances
+func (h *TestHarness) SetCollectorServiceFactory(factory func() CollectorService) {
+	h.serviceFactory = factory
+}
+
+// SetParallel sets the number of contracts run concurrently; 0 or 1 runs them sequentially
+func (h *TestHarness) SetParallel(workers int) {
+	h.parallel = workers
+}
+
+// SetTimeout sets how long each contract may run before it fails; 0 disables the timeout.
+// A contract's own timeout overrides it.
+func (h *TestHarness) SetTimeout(timeout time.Duration) {
+	h.timeout = timeout
+}
+
+// SetFailFast stops scheduling new contracts once one has failed
+func (h *TestHarness) SetFailFast(failFast bool) {
+	h.failFast = failFast
+}
+
+// SetRetryPolicy sets how failed contracts are re-run
+func (h *TestHarness) SetRetryPolicy(policy RetryPolicy) {
+	h.retry = policy
+}
+
+// SetProcessorFactory sets the factory that creates processors when no collector service is set.
+// Without one, contracts whose configuration has processors fail rather than skip them.
+func (h *TestHarness) SetProcessorFactory(factory ProcessorFactory) {
+	h.processorFactory = factory
+}
+
+// SetUpdateGolden sets whether golden snapshots are rewritten from the output instead of compared
+func (h *TestHarness) SetUpdateGolden(update bool) {
+	h.updateGolden = update
+}
+
 // RunTests runs all tests for the given contracts
 func (h *TestHarness) RunTests(contracts []*contract.Contract) TestResults {
 	startTime := time.Now()
@@ -97,29 +158,137 @@ func (h *TestHarness) RunTests(contracts []*contract.Contract) TestResults {
 		zap.String("mode", string(h.mode)),
 		zap.Int("contract_count", len(contracts)))
 
-	for _, contract := range contracts {
-		result := h.runSingleTest(contract)
-		results.Results = append(results.Results, result)
-
-		if result.Valid {
+	contracts = h.resolvePipelines(contracts)
+	results.Results = h.runContracts(contracts)
+	for _, result := range results.Results {
+		switch {
+		case result.Skipped:
+			results.SkippedTests++
+		case result.Valid:
 			results.PassedTests++
-		} else {
+		default:
 			results.FailedTests++
 		}
+		if result.Flaky {
+			results.FlakyTests++
+		}
 	}
 
-	results.TotalTests = len(contracts)
+	results.TotalTests = len(results.Results)
+	results.NotRunTests = len(contracts) - len(results.Results)
 	results.Duration = time.Since(startTime)
 
+	if results.NotRunTests > 0 {
+		h.logger.Warn("Fail-fast stopped the run after a failure",
+			zap.Int("not_run", results.NotRunTests))
+	}
+
 	h.logger.Info("Test execution completed",
 		zap.Int("total", results.TotalTests),
 		zap.Int("passed", results.PassedTests),
 		zap.Int("failed", results.FailedTests),
+		zap.Int("skipped", results.SkippedTests),
+		zap.Int("flaky", results.FlakyTests),
 		zap.Duration("duration", results.Duration))
 
 	return results
 }
 
+// runContracts runs every contract, on a pool of workers when running in parallel, and
+// returns the results in contract order. With fail-fast, contracts not yet scheduled when
+// one fails are left out of the results.
+func (h *TestHarness) runContracts(contracts []*contract.Contract) []TestResult {
+	workers := h.parallel
+	if workers > len(contracts) {
+		workers = len(contracts)
+	}
+	if workers > 1 && h.collectorService != nil && h.serviceFactory == nil {
+		h.logger.Warn("A single collector service cannot be shared between workers, running sequentially",
+			zap.Int("parallel", workers))
+		workers = 1
+	}
+
+	results := make([]TestResult, len(contracts))
+	ran := make([]bool, len(contracts))
+	var failed atomic.Bool
+	// run returns the harness the worker continues with, which is renewed after a timeout
+	run := func(worker *TestHarness, i int) *TestHarness {
+		results[i], worker = worker.runWithRetry(contracts[i])
+		ran[i] = true
+		if !results[i].Valid {
+			failed.Store(true)
+		}
+		return worker
+	}
+
+	if workers <= 1 {
+		worker := h
+		for i := range contracts {
+			if h.failFast && failed.Load() {
+				break
+			}
+			worker = run(worker, i)
+		}
+		return completed(results, ran)
+	}
+
+	h.logger.Debug("Running contracts in parallel", zap.Int("workers", workers))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for id := 0; id < workers; id++ {
+		worker := h.newWorker(id)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				worker = run(worker, i)
+			}
+		}()
+	}
+	for i := range contracts {
+		if h.failFast && failed.Load() {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return completed(results, ran)
+}
+
+// completed keeps the results of the contracts that ran, in contract order
+func completed(results []TestResult, ran []bool) []TestResult {
+	kept := results[:0]
+	for i, result := range results {
+		if ran[i] {
+			kept = append(kept, result)
+		}
+	}
+	return kept
+}
+
+// newWorker returns a copy of the harness with its own generator, matcher and collector
+// service, so concurrent contracts share no mutable state
+func (h *TestHarness) newWorker(id int) *TestHarness {
+	worker := h.renewed()
+	worker.logger = h.logger.With(zap.Int("worker", id))
+	return worker
+}
+
+// renewed returns a copy of the harness with its own generator, matcher and collector
+// service. After a timeout the abandoned stage may still be using the originals.
+func (h *TestHarness) renewed() *TestHarness {
+	worker := *h
+	worker.generator = generator.NewGenerator()
+	worker.matcher = matcher.NewMatcher()
+	if h.serviceFactory != nil {
+		worker.collectorService = h.serviceFactory()
+	}
+	return &worker
+}
+
 // runSingleTest runs a single test for a contract
 func (h *TestHarness) runSingleTest(contractDef *contract.Contract) TestResult {
 	startTime := time.Now()
@@ -134,74 +303,167 @@ func (h *TestHarness) runSingleTest(contractDef *contract.Contract) TestResult {
 		zap.String("pipeline", contractDef.Pipeline),
 		zap.String("version", contractDef.Version))
 
+	if h.mode != TestModePipeline && h.mode != TestModeProcessor {
+		result.Errors = append(result.Errors, fmt.Sprintf("Unknown test mode: %s", h.mode))
+		result.Valid = false
+		result.Duration = time.Since(startTime)
+		return result
+	}
+
+	timeout := h.timeout
+	if override, err := contractDef.GetTimeout(); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		result.Valid = false
+		result.Duration = time.Since(startTime)
+		return result
+	} else if override > 0 {
+		timeout = override
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
 	// Generate input data from contract
-	inputData := h.generator.GenerateFromContract(contractDef)
+	var inputData contract.OpenTelemetryData
+	if err := runStage(ctx, StageGeneration, timeout, func(ctx context.Context) error {
+		inputData = h.generator.GenerateFromContract(contractDef)
+		return ctx.Err()
+	}); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		result.TimedOut = true
+		result.Valid = false
+		result.Duration = time.Since(startTime)
+		return result
+	}
 
 	result.InputData = inputData
 
 	// Run the test based on mode
 	var outputData contract.OpenTelemetryData
-	var err error
-	switch h.mode {
-	case TestModePipeline:
-		outputData, err = h.runPipelineTest(contractDef, inputData)
-	case TestModeProcessor:
-		outputData, err = h.runProcessorTest(contractDef, inputData)
-	default:
-		result.Errors = append(result.Errors, fmt.Sprintf("Unknown test mode: %s", h.mode))
+	if err := runStage(ctx, StageProcessing, timeout, func(ctx context.Context) error {
+		var err error
+		if h.mode == TestModePipeline {
+			outputData, err = h.runPipelineTest(ctx, contractDef, inputData)
+		} else {
+			outputData, err = h.runProcessorTest(ctx, contractDef, inputData)
+		}
+		return err
+	}); err != nil {
+		var timeoutErr *StageTimeoutError
+		if errors.As(err, &timeoutErr) {
+			result.Errors = append(result.Errors, err.Error())
+			result.TimedOut = true
+		} else {
+			result.Errors = append(result.Errors, fmt.Sprintf("Test execution failed: %v", err))
+		}
 		result.Valid = false
 		result.Duration = time.Since(startTime)
 		return result
 	}
 
-	if err != nil {
-		result.Errors = append(result.Errors, fmt.Sprintf("Test execution failed: %v", err))
+	result.OutputData = outputData
+
+	// Validate the output against contract matchers and its golden snapshot
+	var validationResult contract.ValidationResult
+	var goldenWarning string
+	var goldenErr error
+	if err := runStage(ctx, StageMatching, timeout, func(ctx context.Context) error {
+		validationResult = h.matcher.Validate(contractDef, inputData, outputData)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if contractDef.Matchers.Golden != nil && !validationResult.Skipped {
+			goldenWarning, goldenErr = h.checkGolden(contractDef, outputData)
+		}
+		return ctx.Err()
+	}); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		result.TimedOut = true
 		result.Valid = false
 		result.Duration = time.Since(startTime)
 		return result
 	}
 
-	result.OutputData = outputData
-
-	// Validate the output against contract matchers
-	validationResult := h.matcher.Validate(contractDef, inputData, outputData)
-	if !validationResult.Valid {
+	if validationResult.Skipped {
+		// The contract's filters excluded the generated input
+		result.Skipped = true
+		result.SkipReason = validationResult.SkipReason
+		result.Valid = true
+	} else if !validationResult.Valid {
+		result.Failures = validationResult.Errors
 		for _, err := range validationResult.Errors {
 			result.Errors = append(result.Errors, err.Message)
+			if err.Diff != nil {
+				result.Diffs = append(result.Diffs, err.Diff)
+			}
 		}
 		result.Valid = false
 	} else {
 		result.Valid = true
 	}
 
+	if goldenWarning != "" {
+		result.Warnings = append(result.Warnings, goldenWarning)
+	}
+	if goldenErr != nil {
+		result.Errors = append(result.Errors, goldenErr.Error())
+		result.Valid = false
+	}
+
 	result.Duration = time.Since(startTime)
 
 	h.logger.Debug("Test completed",
 		zap.String("publisher", contractDef.Publisher),
 		zap.Bool("valid", result.Valid),
+		zap.Bool("skipped", result.Skipped),
 		zap.Int("errors", len(result.Errors)),
 		zap.Duration("duration", result.Duration))
 
 	return result
 }
 
+// checkGolden compares the normalised output with the contract's golden snapshot, or
+// rewrites the snapshot when updating and returns a warning saying so
+func (h *TestHarness) checkGolden(contractDef *contract.Contract, outputData contract.OpenTelemetryData) (string, error) {
+	mask := contractDef.Matchers.Golden.Mask
+	path := golden.Path(contractDef)
+
+	actual, err := golden.Normalize(outputData, mask)
+	if err != nil {
+		return "", fmt.Errorf("failed to normalise output for golden snapshot: %w", err)
+	}
+
+	if h.updateGolden {
+		if err := golden.Write(path, actual); err != nil {
+			return "", err
+		}
+		h.logger.Info("Updated golden snapshot", zap.String("path", path))
+		return fmt.Sprintf("updated golden snapshot %s", path), nil
+	}
+
+	return "", golden.Compare(path, actual, mask)
+}
+
 // runPipelineTest runs a test in pipeline mode
-func (h *TestHarness) runPipelineTest(contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
+func (h *TestHarness) runPipelineTest(ctx context.Context, contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
 	h.logger.Debug("Running pipeline test",
 		zap.String("publisher", contractDef.Publisher),
 		zap.String("pipeline", contractDef.Pipeline))
 
 	// Use collector service if available, otherwise fall back to simulation
 	if h.collectorService != nil {
-		return h.runPipelineTestWithCollector(contractDef, inputData)
+		return h.runPipelineTestWithCollector(ctx, contractDef, inputData)
 	}
 
 	// Fall back to simulation mode
-	return h.runPipelineTestSimulation(contractDef, inputData)
+	return h.runPipelineTestSimulation(ctx, contractDef, inputData)
 }
 
 // runPipelineTestWithCollector runs a test using the real collector service
-func (h *TestHarness) runPipelineTestWithCollector(contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
+func (h *TestHarness) runPipelineTestWithCollector(ctx context.Context, contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
 	h.logger.Debug("Running pipeline test with real collector service")
 
 	// Start the collector service
@@ -214,8 +476,9 @@ func (h *TestHarness) runPipelineTestWithCollector(contractDef *contract.Contrac
 		}
 	}()
 
-	// Process data through the collector
-	output, err := h.collectorService.ProcessData(inputData)
+	// Process data through the collector, each signal through the contract's pipeline
+	h.collectorService.SetPipeline(contractDef.Pipeline)
+	output, err := h.collectorService.ProcessData(ctx, inputData)
 	if err != nil {
 		return contract.OpenTelemetryData{}, fmt.Errorf("failed to process data through collector: %w", err)
 	}
@@ -235,344 +498,42 @@ func (h *TestHarness) runPipelineTestWithCollector(contractDef *contract.Contrac
 }
 
 // runPipelineTestSimulation runs a test using simulation mode
-func (h *TestHarness) runPipelineTestSimulation(contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
+func (h *TestHarness) runPipelineTestSimulation(ctx context.Context, contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
 	h.logger.Debug("Running pipeline test in simulation mode")
-
-	// Create a mock consumer to capture output data
-	mockConsumer := NewMockConsumer()
-	defer mockConsumer.Clear()
-
-	// Create a simple pipeline simulation
-	ctx := context.Background()
-
-	// Process traces if present
-	if inputData.Traces.ResourceSpans().Len() > 0 {
-		h.logger.Debug("Processing traces through pipeline")
-		if err := h.processTracesThroughPipeline(ctx, inputData.Traces, mockConsumer); err != nil {
-			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process traces: %w", err)
-		}
-	}
-
-	// Process metrics if present (only if metrics exist and have data)
-	if inputData.Metrics.ResourceMetrics().Len() > 0 {
-		h.logger.Debug("Processing metrics through pipeline")
-		if err := h.processMetricsThroughPipeline(ctx, inputData.Metrics, mockConsumer); err != nil {
-			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process metrics: %w", err)
-		}
-	} else {
-		h.logger.Debug("No metrics to process")
-	}
-
-	// Process logs if present (only if logs exist and have data)
-	if inputData.Logs.ResourceLogs().Len() > 0 {
-		h.logger.Debug("Processing logs through pipeline")
-		if err := h.processLogsThroughPipeline(ctx, inputData.Logs, mockConsumer); err != nil {
-			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process logs: %w", err)
-		}
-	} else {
-		h.logger.Debug("No logs to process")
-	}
-
-	// Collect output data
-	outputData := contract.OpenTelemetryData{
-		Time:    time.Now(),
-		Traces:  ptrace.NewTraces(),
-		Metrics: pmetric.NewMetrics(),
-		Logs:    plog.NewLogs(),
-	}
-
-	// Get processed traces
-	if traces := mockConsumer.GetTraces(); len(traces) > 0 {
-		outputData.Traces = traces[0] // Take the first batch for now
-	}
-
-	// Get processed metrics
-	if metrics := mockConsumer.GetMetrics(); len(metrics) > 0 {
-		outputData.Metrics = metrics[0] // Take the first batch for now
-	}
-
-	// Get processed logs
-	if logs := mockConsumer.GetLogs(); len(logs) > 0 {
-		outputData.Logs = logs[0] // Take the first batch for now
-	}
-
-	return outputData, nil
+	return h.runEngine(ctx, contractDef, inputData)
 }
 
 // runProcessorTest runs a test in processor mode
-func (h *TestHarness) runProcessorTest(contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
+func (h *TestHarness) runProcessorTest(ctx context.Context, contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
 	h.logger.Debug("Running processor test",
 		zap.String("publisher", contractDef.Publisher),
 		zap.String("pipeline", contractDef.Pipeline))
-
-	// Create a mock consumer to capture output data
-	mockConsumer := NewMockConsumer()
-	defer mockConsumer.Clear()
-
-	ctx := context.Background()
-
-	// Process traces if present
-	if inputData.Traces.ResourceSpans().Len() > 0 {
-		h.logger.Debug("Processing traces through processors")
-		if err := h.processTracesThroughProcessors(ctx, inputData.Traces, mockConsumer); err != nil {
-			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process traces: %w", err)
-		}
-	}
-
-	// Process metrics if present (only if metrics exist and have data)
-	if inputData.Metrics.ResourceMetrics().Len() > 0 {
-		h.logger.Debug("Processing metrics through processors")
-		if err := h.processMetricsThroughProcessors(ctx, inputData.Metrics, mockConsumer); err != nil {
-			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process metrics: %w", err)
-		}
-	} else {
-		h.logger.Debug("No metrics to process")
-	}
-
-	// Process logs if present (only if logs exist and have data)
-	if inputData.Logs.ResourceLogs().Len() > 0 {
-		h.logger.Debug("Processing logs through processors")
-		if err := h.processLogsThroughProcessors(ctx, inputData.Logs, mockConsumer); err != nil {
-			return contract.OpenTelemetryData{}, fmt.Errorf("failed to process logs: %w", err)
-		}
-	} else {
-		h.logger.Debug("No logs to process")
-	}
-
-	// Collect output data
-	outputData := contract.OpenTelemetryData{
-		Time:    time.Now(),
-		Traces:  ptrace.NewTraces(),
-		Metrics: pmetric.NewMetrics(),
-		Logs:    plog.NewLogs(),
-	}
-
-	// Get processed traces
-	if traces := mockConsumer.GetTraces(); len(traces) > 0 {
-		outputData.Traces = traces[0]
-	}
-
-	// Get processed metrics
-	if metrics := mockConsumer.GetMetrics(); len(metrics) > 0 {
-		outputData.Metrics = metrics[0]
-	}
-
-	// Get processed logs
-	if logs := mockConsumer.GetLogs(); len(logs) > 0 {
-		outputData.Logs = logs[0]
-	}
-
-	return outputData, nil
-}
-
-// processTracesThroughPipeline processes traces through the configured pipeline
-func (h *TestHarness) processTracesThroughPipeline(ctx context.Context, traces ptrace.Traces, consumer *MockConsumer) error {
-	// In a real implementation, this would:
-	// 1. Start a collector instance with the provided configuration
-	// 2. Send traces through the configured pipeline
-	// 3. Capture output from exporters
-
-	// For now, we'll simulate basic processing based on the configuration
-	h.logger.Debug("Simulating trace processing through pipeline")
-
-	// Apply basic transformations based on processor configuration
-	processedTraces := h.applyTraceTransformations(traces)
-
-	// Send to consumer
-	return consumer.ConsumeTraces(ctx, processedTraces)
-}
-
-// processMetricsThroughPipeline processes metrics through the configured pipeline
-func (h *TestHarness) processMetricsThroughPipeline(ctx context.Context, metrics pmetric.Metrics, consumer *MockConsumer) error {
-	h.logger.Debug("Simulating metric processing through pipeline")
-
-	// Apply basic transformations based on processor configuration
-	processedMetrics := h.applyMetricTransformations(metrics)
-
-	// Send to consumer
-	return consumer.ConsumeMetrics(ctx, processedMetrics)
-}
-
-// processLogsThroughPipeline processes logs through the configured pipeline
-func (h *TestHarness) processLogsThroughPipeline(ctx context.Context, logs plog.Logs, consumer *MockConsumer) error {
-	h.logger.Debug("Simulating log processing through pipeline")
-
-	// Apply basic transformations based on processor configuration
-	processedLogs := h.applyLogTransformations(logs)
-
-	// Send to consumer
-	return consumer.ConsumeLogs(ctx, processedLogs)
-}
-
-// processTracesThroughProcessors processes traces through individual processors
-func (h *TestHarness) processTracesThroughProcessors(ctx context.Context, traces ptrace.Traces, consumer *MockConsumer) error {
-	h.logger.Debug("Simulating trace processing through processors")
-
-	// Apply processor-specific transformations
-	processedTraces := h.applyTraceTransformations(traces)
-
-	// Send to consumer
-	return consumer.ConsumeTraces(ctx, processedTraces)
+	return h.runEngine(ctx, contractDef, inputData)
 }
 
-// processMetricsThroughProcessors processes metrics through individual processors
-func (h *TestHarness) processMetricsThroughProcessors(ctx context.Context, metrics pmetric.Metrics, consumer *MockConsumer) error {
-	h.logger.Debug("Simulating metric processing through processors")
-
-	// Apply processor-specific transformations
-	processedMetrics := h.applyMetricTransformations(metrics)
-
-	// Send to consumer
-	return consumer.ConsumeMetrics(ctx, processedMetrics)
-}
-
-// processLogsThroughProcessors processes logs through individual processors
-func (h *TestHarness) processLogsThroughProcessors(ctx context.Context, logs plog.Logs, consumer *MockConsumer) error {
-	h.logger.Debug("Simulating log processing through processors")
-
-	// Apply processor-specific transformations
-	processedLogs := h.applyLogTransformations(logs)
-
-	// Send to consumer
-	return consumer.ConsumeLogs(ctx, processedLogs)
-}
-
-// applyTraceTransformations applies transformations to traces based on processor configuration
-func (h *TestHarness) applyTraceTransformations(traces ptrace.Traces) ptrace.Traces {
-	// Create a copy to avoid modifying the original
-	processedTraces := ptrace.NewTraces()
-	traces.CopyTo(processedTraces)
-
-	// Apply transformations based on processor configuration
-	// This is a simplified implementation - in a real scenario, you would:
-	// 1. Parse the processor configuration
-	// 2. Create processor instances
-	// 3. Apply each processor in sequence
-
-	h.logger.Debug("Applying trace transformations", zap.Int("processor_count", len(h.config.Processors)))
-
-	// For now, we'll apply some basic transformations based on common processors
-	for processorName, processorConfig := range h.config.Processors {
-		h.logger.Debug("Processing with processor", zap.String("processor", processorName))
-
-		// Apply transformations based on processor type
-		switch processorName {
-		case "transform":
-			processedTraces = h.applyTransformProcessor(processedTraces, processorConfig)
-		case "attributes":
-			processedTraces = h.applyAttributesProcessor(processedTraces, processorConfig)
-		case "filter":
-			processedTraces = h.applyFilterProcessor(processedTraces, processorConfig)
-		default:
-			h.logger.Debug("Unknown processor type, skipping", zap.String("processor", processorName))
-		}
+// runEngine runs the input through the contract's pipeline with processors from the
+// harness's processor factory, the same engine the collector service uses
+func (h *TestHarness) runEngine(ctx context.Context, contractDef *contract.Contract, inputData contract.OpenTelemetryData) (contract.OpenTelemetryData, error) {
+	if h.processorFactory == nil && len(h.config.Processors) > 0 {
+		return contract.OpenTelemetryData{}, fmt.Errorf("no processor factory set for the %d configured processors", len(h.config.Processors))
 	}
-
-	return processedTraces
-}
-
-// applyMetricTransformations applies transformations to metrics based on processor configuration
-func (h *TestHarness) applyMetricTransformations(metrics pmetric.Metrics) pmetric.Metrics {
-	// Create a copy to avoid modifying the original
-	processedMetrics := pmetric.NewMetrics()
-	metrics.CopyTo(processedMetrics)
-
-	h.logger.Debug("Applying metric transformations", zap.Int("processor_count", len(h.config.Processors)))
-
-	// Apply transformations based on processor configuration
-	for processorName, processorConfig := range h.config.Processors {
-		h.logger.Debug("Processing with processor", zap.String("processor", processorName))
-
-		switch processorName {
-		case "transform":
-			processedMetrics = h.applyMetricTransformProcessor(processedMetrics, processorConfig)
-		case "attributes":
-			processedMetrics = h.applyMetricAttributesProcessor(processedMetrics, processorConfig)
-		case "filter":
-			processedMetrics = h.applyMetricFilterProcessor(processedMetrics, processorConfig)
-		default:
-			h.logger.Debug("Unknown processor type, skipping", zap.String("processor", processorName))
-		}
+	processors, err := BuildProcessors(h.config, h.processorFactory)
+	if err != nil {
+		return contract.OpenTelemetryData{}, err
 	}
 
-	return processedMetrics
-}
-
-// applyLogTransformations applies transformations to logs based on processor configuration
-func (h *TestHarness) applyLogTransformations(logs plog.Logs) plog.Logs {
-	// Create a copy to avoid modifying the original
-	processedLogs := plog.NewLogs()
-	logs.CopyTo(processedLogs)
-
-	h.logger.Debug("Applying log transformations", zap.Int("processor_count", len(h.config.Processors)))
-
-	// Apply transformations based on processor configuration
-	for processorName, processorConfig := range h.config.Processors {
-		h.logger.Debug("Processing with processor", zap.String("processor", processorName))
-
-		switch processorName {
-		case "transform":
-			processedLogs = h.applyLogTransformProcessor(processedLogs, processorConfig)
-		case "attributes":
-			processedLogs = h.applyLogAttributesProcessor(processedLogs, processorConfig)
-		case "filter":
-			processedLogs = h.applyLogFilterProcessor(processedLogs, processorConfig)
-		default:
-			h.logger.Debug("Unknown processor type, skipping", zap.String("processor", processorName))
-		}
+	engine := NewEngine(h.config, processors, h.logger)
+	if err := engine.Start(ctx); err != nil {
+		return contract.OpenTelemetryData{}, err
 	}
+	defer func() {
+		// Processors are shut down even when the contract's deadline has passed
+		if err := engine.Shutdown(context.WithoutCancel(ctx)); err != nil {
+			h.logger.Error("Failed to stop processors", zap.Error(err))
+		}
+	}()
 
-	return processedLogs
-}
-
-// Processor transformation methods (simplified implementations)
-func (h *TestHarness) applyTransformProcessor(traces ptrace.Traces, config interface{}) ptrace.Traces {
-	// Simplified transform processor implementation
-	// In a real implementation, this would parse the config and apply transformations
-	h.logger.Debug("Applying transform processor to traces")
-	return traces
-}
-
-func (h *TestHarness) applyAttributesProcessor(traces ptrace.Traces, config interface{}) ptrace.Traces {
-	// Simplified attributes processor implementation
-	h.logger.Debug("Applying attributes processor to traces")
-	return traces
-}
-
-func (h *TestHarness) applyFilterProcessor(traces ptrace.Traces, config interface{}) ptrace.Traces {
-	// Simplified filter processor implementation
-	h.logger.Debug("Applying filter processor to traces")
-	return traces
-}
-
-func (h *TestHarness) applyMetricTransformProcessor(metrics pmetric.Metrics, config interface{}) pmetric.Metrics {
-	h.logger.Debug("Applying transform processor to metrics")
-	return metrics
-}
-
-func (h *TestHarness) applyMetricAttributesProcessor(metrics pmetric.Metrics, config interface{}) pmetric.Metrics {
-	h.logger.Debug("Applying attributes processor to metrics")
-	return metrics
-}
-
-func (h *TestHarness) applyMetricFilterProcessor(metrics pmetric.Metrics, config interface{}) pmetric.Metrics {
-	h.logger.Debug("Applying filter processor to metrics")
-	return metrics
-}
-
-func (h *TestHarness) applyLogTransformProcessor(logs plog.Logs, config interface{}) plog.Logs {
-	h.logger.Debug("Applying transform processor to logs")
-	return logs
-}
-
-func (h *TestHarness) applyLogAttributesProcessor(logs plog.Logs, config interface{}) plog.Logs {
-	h.logger.Debug("Applying attributes processor to logs")
-	return logs
-}
-
-func (h *TestHarness) applyLogFilterProcessor(logs plog.Logs, config interface{}) plog.Logs {
-	h.logger.Debug("Applying filter processor to logs")
-	return logs
+	return engine.Process(ctx, contractDef.Pipeline, inputData)
 }
 
 // MockConsumer is a mock consumer for testing
@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package harness
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Pipeline signal types
+const (
+	SignalTraces  = "traces"
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+)
+
+// ComponentID identifies a collector component as type[/name], such as attributes/redact
+type ComponentID struct {
+	Type string
+	Name string
+}
+
+// ParseComponentID parses a type[/name] component ID
+func ParseComponentID(id string) (ComponentID, error) {
+	componentType, name, hasName := strings.Cut(strings.TrimSpace(id), "/")
+	if componentType == "" {
+		return ComponentID{}, fmt.Errorf("invalid component ID %q: type is empty", id)
+	}
+	if hasName && name == "" {
+		return ComponentID{}, fmt.Errorf("invalid component ID %q: name is empty", id)
+	}
+	return ComponentID{Type: componentType, Name: name}, nil
+}
+
+// String returns the ID as written in collector configuration
+func (id ComponentID) String() string {
+	if id.Name == "" {
+		return id.Type
+	}
+	return id.Type + "/" + id.Name
+}
+
+// Pipeline is a service pipeline with its components in configured order
+type Pipeline struct {
+	ID         ComponentID // Signal type and optional name, such as traces/checkout
+	Receivers  []ComponentID
+	Processors []ComponentID
+	Exporters  []ComponentID
+}
+
+// Signal returns the signal the pipeline carries
+func (p Pipeline) Signal() string {
+	return p.ID.Type
+}
+
+// Pipelines returns the pipelines declared under service.pipelines, sorted by ID
+func (c CollectorConfig) Pipelines() ([]Pipeline, error) {
+	raw, ok := c.Service["pipelines"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	declared, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("service.pipelines must be a map")
+	}
+
+	pipelines := make([]Pipeline, 0, len(declared))
+	for key, value := range declared {
+		id, err := ParseComponentID(key)
+		if err != nil {
+			return nil, fmt.Errorf("pipeline %s: %w", key, err)
+		}
+		switch id.Type {
+		case SignalTraces, SignalMetrics, SignalLogs:
+		default:
+			return nil, fmt.Errorf("pipeline %s: unknown signal %q (must be traces, metrics or logs)", key, id.Type)
+		}
+
+		pipeline := Pipeline{ID: id}
+		components, _ := value.(map[string]interface{})
+		for _, section := range []struct {
+			name string
+			ids  *[]ComponentID
+		}{
+			{"receivers", &pipeline.Receivers},
+			{"processors", &pipeline.Processors},
+			{"exporters", &pipeline.Exporters},
+		} {
+			ids, err := componentIDs(components[section.name])
+			if err != nil {
+				return nil, fmt.Errorf("pipeline %s: %s: %w", key, section.name, err)
+			}
+			*section.ids = ids
+		}
+		pipelines = append(pipelines, pipeline)
+	}
+
+	sort.Slice(pipelines, func(i, j int) bool {
+		return pipelines[i].ID.String() < pipelines[j].ID.String()
+	})
+	return pipelines, nil
+}
+
+// SelectPipeline returns the pipeline of a signal that a contract's pipeline name refers
+// to. The name may be a full ID (traces/checkout) or a bare name (checkout), and a full
+// ID of another signal selects the same-named pipeline of this one. Only an empty name
+// or a bare signal name falls back to the signal's only pipeline, or else its unnamed
+// pipeline; any other name that no pipeline of the signal has is an error.
+func (c CollectorConfig) SelectPipeline(signal, name string) (Pipeline, bool, error) {
+	pipelines, err := c.Pipelines()
+	if err != nil {
+		return Pipeline{}, false, err
+	}
+
+	var candidates []Pipeline
+	for _, pipeline := range pipelines {
+		if pipeline.Signal() == signal {
+			candidates = append(candidates, pipeline)
+		}
+	}
+	if len(candidates) == 0 {
+		return Pipeline{}, false, nil
+	}
+
+	wanted := name
+	if id, err := ParseComponentID(name); err == nil {
+		switch id.Type {
+		case SignalTraces, SignalMetrics, SignalLogs:
+			wanted = id.Name
+		}
+	}
+	if wanted != "" {
+		for _, pipeline := range candidates {
+			if pipeline.ID.Name == wanted {
+				return pipeline, true, nil
+			}
+		}
+		return Pipeline{}, false, fmt.Errorf("no %s pipeline named %q", signal, wanted)
+	}
+
+	if len(candidates) == 1 {
+		return candidates[0], true, nil
+	}
+	for _, pipeline := range candidates {
+		if pipeline.ID.Name == "" {
+			return pipeline, true, nil
+		}
+	}
+	return Pipeline{}, false, nil
+}
+
+// ProcessorChain returns the processors a signal runs through, in order. With
+// service.pipelines declared this is the selected pipeline's processors list, empty
+// when the signal has no pipeline; otherwise every configured processor runs, sorted by ID.
+func (c CollectorConfig) ProcessorChain(signal, pipelineName string) ([]ComponentID, error) {
+	pipelines, err := c.Pipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pipelines) == 0 {
+		keys := make([]string, 0, len(c.Processors))
+		for key := range c.Processors {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return componentIDs(keys)
+	}
+
+	pipeline, ok, err := c.SelectPipeline(signal, pipelineName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	for _, id := range pipeline.Processors {
+		if _, exists := c.Processors[id.String()]; !exists {
+			return nil, fmt.Errorf("pipeline %s: processor %s not found in processors section", pipeline.ID, id)
+		}
+	}
+	return pipeline.Processors, nil
+}
+
+// componentIDs parses a list of component IDs from configuration
+func componentIDs(raw interface{}) ([]ComponentID, error) {
+	var values []string
+	switch list := raw.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		values = list
+	case []interface{}:
+		for _, item := range list {
+			value, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("component ID %v must be a string", item)
+			}
+			values = append(values, value)
+		}
+	default:
+		return nil, fmt.Errorf("must be a list of component IDs")
+	}
+
+	ids := make([]ComponentID, 0, len(values))
+	for _, value := range values {
+		id, err := ParseComponentID(value)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
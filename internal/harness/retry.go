@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package harness
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.uber.org/zap"
+)
+
+// RetryPolicy controls how failed contracts are re-run
+type RetryPolicy struct {
+	MaxAttempts       int // Attempts per contract, including the first; 0 or 1 disables retries
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+}
+
+// Backoff returns the wait before the given retry, counting the first retry as 1
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	multiplier := p.BackoffMultiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || backoff < float64(p.MaxBackoff)); i++ {
+		backoff *= multiplier
+	}
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// runWithRetry runs a contract, re-running it with backoff while it fails and attempts
+// remain. A contract that passes only after a retry is flagged as flaky. It returns the
+// harness to run later contracts with, renewed when an attempt timed out so that no
+// component is shared with the abandoned stage.
+func (h *TestHarness) runWithRetry(contractDef *contract.Contract) (TestResult, *TestHarness) {
+	if result, ok := h.unmatchedResult(contractDef); ok {
+		return result, h
+	}
+
+	maxAttempts := h.retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var failures []string
+	for attempt := 1; ; attempt++ {
+		result := h.runSingleTest(contractDef)
+		result.Attempts = attempt
+		if result.TimedOut {
+			if h.collectorService != nil && h.serviceFactory == nil {
+				h.logger.Warn("A timed out stage may still be using the shared collector service",
+					zap.String("publisher", contractDef.Publisher))
+			}
+			h = h.renewed()
+		}
+		if result.Valid || attempt == maxAttempts {
+			if result.Valid && attempt > 1 {
+				result.Flaky = true
+				result.Warnings = append(result.Warnings,
+					fmt.Sprintf("flaky: passed on attempt %d of %d", attempt, maxAttempts))
+				result.Warnings = append(result.Warnings, failures...)
+			}
+			return result, h
+		}
+
+		failures = append(failures, fmt.Sprintf("attempt %d failed: %s", attempt, firstError(result)))
+		backoff := h.retry.Backoff(attempt)
+		h.logger.Info("Retrying failed contract",
+			zap.String("publisher", contractDef.Publisher),
+			zap.String("pipeline", contractDef.Pipeline),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("backoff", backoff))
+		time.Sleep(backoff)
+	}
+}
+
+// firstError returns the first error of a failed result
+func firstError(result TestResult) string {
+	if len(result.Errors) == 0 {
+		return "contract validation failed"
+	}
+	return result.Errors[0]
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package harness
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.uber.org/zap"
+)
+
+// PipelineEnricher adds tags, metadata and descriptions to the pipeline info registered
+// for each pipeline before contracts' selectors are resolved
+type PipelineEnricher func(info *contract.PipelineInfo)
+
+// pipelineTypes maps signals to the pipeline types selectors match on
+var pipelineTypes = map[string]string{
+	SignalTraces:  "trace",
+	SignalMetrics: "metric",
+	SignalLogs:    "log",
+}
+
+// PipelineInfos returns the selector info for every pipeline under service.pipelines.
+// The ID and name are the pipeline ID, the type is trace, metric or log, and the
+// pipeline's components are listed in its metadata.
+func (c CollectorConfig) PipelineInfos() ([]*contract.PipelineInfo, error) {
+	pipelines, err := c.Pipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*contract.PipelineInfo, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		infos = append(infos, &contract.PipelineInfo{
+			ID:   pipeline.ID.String(),
+			Name: pipeline.ID.String(),
+			Type: pipelineTypes[pipeline.Signal()],
+			Tags: map[string]string{},
+			Metadata: map[string]string{
+				"receivers":  joinComponentIDs(pipeline.Receivers),
+				"processors": joinComponentIDs(pipeline.Processors),
+				"exporters":  joinComponentIDs(pipeline.Exporters),
+			},
+		})
+	}
+	return infos, nil
+}
+
+// joinComponentIDs lists component IDs as a comma-separated string
+func joinComponentIDs(ids []ComponentID) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return strings.Join(values, ",")
+}
+
+// SetPipelineEnricher sets the enricher applied to each registered pipeline
+func (h *TestHarness) SetPipelineEnricher(enricher PipelineEnricher) {
+	h.pipelineEnricher = enricher
+}
+
+// resolvePipelines replaces each contract that uses pipeline selectors with one copy per
+// pipeline its match policy selects, bound to that pipeline. A contract that matches no
+// pipeline, or matches ambiguously, is kept and recorded so that it is reported as a
+// failure rather than run.
+func (h *TestHarness) resolvePipelines(contracts []*contract.Contract) []*contract.Contract {
+	h.unmatched = make(map[*contract.Contract]string)
+	resolved := make([]*contract.Contract, 0, len(contracts))
+	var selector *contract.PipelineSelectorService
+	var selectorErr error
+	for _, contractDef := range contracts {
+		if !contractDef.HasPipelineSelectors() {
+			resolved = append(resolved, contractDef)
+			continue
+		}
+
+		if selector == nil && selectorErr == nil {
+			selector, selectorErr = h.pipelineSelector()
+		}
+		var matches []*contract.PipelineInfo
+		err := selectorErr
+		if err == nil {
+			matches, err = selector.SelectPipelines(contractDef.PipelineSelectors)
+		}
+		var ambiguous *contract.AmbiguousPipelineError
+		if errors.As(err, &ambiguous) {
+			h.unmatched[contractDef] = err.Error()
+			resolved = append(resolved, contractDef)
+			continue
+		}
+		if err != nil {
+			h.unmatched[contractDef] = fmt.Sprintf("failed to resolve pipeline selectors: %v", err)
+			resolved = append(resolved, contractDef)
+			continue
+		}
+		if len(matches) == 0 {
+			h.unmatched[contractDef] = noPipelineMatched(selector)
+			resolved = append(resolved, contractDef)
+			continue
+		}
+
+		for _, match := range matches {
+			h.logger.Debug("Resolved pipeline selectors",
+				zap.String("publisher", contractDef.Publisher),
+				zap.String("pipeline", match.ID))
+			bound := *contractDef
+			bound.Pipeline = match.ID
+			resolved = append(resolved, &bound)
+		}
+	}
+	return resolved
+}
+
+// pipelineSelector registers the info of every configured pipeline with a new selector service
+func (h *TestHarness) pipelineSelector() (*contract.PipelineSelectorService, error) {
+	infos, err := h.config.PipelineInfos()
+	if err != nil {
+		return nil, err
+	}
+	if h.pipelineEnricher != nil {
+		for _, info := range infos {
+			h.pipelineEnricher(info)
+		}
+	}
+
+	selector := contract.NewPipelineSelectorService()
+	selector.RegisterPipelines(infos)
+	return selector, nil
+}
+
+// noPipelineMatched describes a contract whose selectors matched none of the pipelines
+func noPipelineMatched(selector *contract.PipelineSelectorService) string {
+	pipelines := selector.ListPipelines()
+	if len(pipelines) == 0 {
+		return "no pipeline matched the contract's pipeline selectors: service.pipelines declares no pipelines"
+	}
+	ids := make([]string, len(pipelines))
+	for i, pipeline := range pipelines {
+		ids[i] = pipeline.ID
+	}
+	return fmt.Sprintf("no pipeline matched the contract's pipeline selectors (pipelines: %s)", strings.Join(ids, ", "))
+}
+
+// unmatchedResult reports a contract whose pipeline selectors could not be resolved
+func (h *TestHarness) unmatchedResult(contractDef *contract.Contract) (TestResult, bool) {
+	reason, ok := h.unmatched[contractDef]
+	if !ok {
+		return TestResult{}, false
+	}
+	return TestResult{
+		Contract: contractDef,
+		Valid:    false,
+		Errors:   []string{reason},
+		Warnings: make([]string, 0),
+		Attempts: 1,
+	}, true
+}
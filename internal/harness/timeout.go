@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Stages of a contract run, named when a contract times out
+const (
+	StageGeneration = "generation"
+	StageProcessing = "processing"
+	StageMatching   = "matching"
+)
+
+// StageTimeoutError reports a contract that ran past its timeout and the stage it was stuck in
+type StageTimeoutError struct {
+	Stage   string
+	Timeout time.Duration
+}
+
+// Error implements error
+func (e *StageTimeoutError) Error() string {
+	return fmt.Sprintf("contract timed out after %s during %s", e.Timeout, e.Stage)
+}
+
+// runStage runs one stage of a contract, returning a StageTimeoutError as soon as ctx
+// is done rather than waiting for a stage that hangs. The stage receives ctx so it can
+// stop early; one that does not is abandoned still running, and the caller must not
+// reuse the components it holds.
+func runStage(ctx context.Context, stage string, timeout time.Duration, run func(ctx context.Context) error) error {
+	if ctx.Done() == nil {
+		return run(ctx)
+	}
+	if ctx.Err() != nil {
+		return &StageTimeoutError{Stage: stage, Timeout: timeout}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && ctx.Err() != nil {
+			return &StageTimeoutError{Stage: stage, Timeout: timeout}
+		}
+		return err
+	case <-ctx.Done():
+		return &StageTimeoutError{Stage: stage, Timeout: timeout}
+	}
+}
@@ -7,14 +7,10 @@ import (
 	"fmt"
 	"math"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/goedelsoup/waveform/internal/contract"
-	"go.opentelemetry.io/collector/pdata/plog"
-	"go.opentelemetry.io/collector/pdata/pmetric"
-	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
 
@@ -41,28 +37,37 @@ func (am *AdvancedMatcher) RegisterCustomValidator(name string, validator func(i
 	am.customValidators[name] = validator
 }
 
-// ValidateRule validates a single validation rule against data
+// ValidateRule validates a single validation rule against data. The rule's field path
+// may reach several items; the rule must hold for every one of them.
 func (am *AdvancedMatcher) ValidateRule(rule contract.ValidationRule, data contract.OpenTelemetryData) error {
-	// Extract the field value
-	fieldValue := am.extractFieldValue(rule.Field, data)
-
 	// Handle conditional logic first
 	if rule.Condition != nil {
 		return am.validateConditionalRule(*rule.Condition, data)
 	}
 
-	// Handle transformation validation
-	if rule.Transform != nil {
-		return am.validateTransformRule(*rule.Transform, fieldValue, data)
-	}
-
-	// Handle temporal validation
-	if rule.Temporal != nil {
-		return am.validateTemporalRule(*rule.Temporal, fieldValue, data)
+	// Extract the field values
+	fieldValues := am.extractFieldValues(rule.Field, data)
+	for i, fieldValue := range fieldValues {
+		var err error
+		switch {
+		case rule.Transform != nil:
+			// Handle transformation validation
+			err = am.validateTransformRule(*rule.Transform, fieldValue, data)
+		case rule.Temporal != nil:
+			// Handle temporal validation
+			err = am.validateTemporalRule(*rule.Temporal, fieldValue, data)
+		default:
+			// Handle basic validation based on operator
+			err = am.validateBasicRule(rule, fieldValue)
+		}
+		if err != nil {
+			if len(fieldValues) > 1 {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+			return err
+		}
 	}
-
-	// Handle basic validation based on operator
-	return am.validateBasicRule(rule, fieldValue)
+	return nil
 }
 
 // validateBasicRule validates basic rules with operators
@@ -136,14 +141,14 @@ func (am *AdvancedMatcher) validateBasicRule(rule contract.ValidationRule, field
 		if rule.Range == nil {
 			return fmt.Errorf("field %s: range not specified for in_range operator", rule.Field)
 		}
-		if !am.inRange(fieldValue, *rule.Range) {
+		if !contract.InRange(fieldValue, *rule.Range) {
 			return fmt.Errorf("field %s: %v not in range [%v, %v]", rule.Field, fieldValue, rule.Range.Min, rule.Range.Max)
 		}
 	case contract.FilterOperatorNotInRange:
 		if rule.Range == nil {
 			return fmt.Errorf("field %s: range not specified for not_in_range operator", rule.Field)
 		}
-		if am.inRange(fieldValue, *rule.Range) {
+		if contract.InRange(fieldValue, *rule.Range) {
 			return fmt.Errorf("field %s: %v should not be in range [%v, %v]", rule.Field, fieldValue, rule.Range.Min, rule.Range.Max)
 		}
 	case contract.FilterOperatorOneOf:
@@ -232,9 +237,10 @@ func (am *AdvancedMatcher) validateTransformRule(transform contract.TransformRul
 		}
 	case "rename":
 		// Validate that source field was removed and target field exists
-		sourceValue := am.extractFieldValue(transform.Source, data)
-		if sourceValue != nil {
-			return fmt.Errorf("expected source field %s to be removed after rename", transform.Source)
+		for _, sourceValue := range am.extractFieldValues(transform.Source, data) {
+			if sourceValue != nil {
+				return fmt.Errorf("expected source field %s to be removed after rename", transform.Source)
+			}
 		}
 		if fieldValue == nil {
 			return fmt.Errorf("expected target field %s to exist after rename", transform.Target)
@@ -273,17 +279,17 @@ func (am *AdvancedMatcher) validateTemporalRule(temporal contract.TemporalRule,
 
 	// Validate threshold if specified
 	if temporal.Threshold != nil && temporal.Comparison != "" {
-		numericValue, err := am.toNumeric(fieldValue)
+		numericValue, err := contract.ToNumeric(fieldValue)
 		if err != nil {
 			return fmt.Errorf("cannot convert field value to numeric for temporal validation: %w", err)
 		}
 
-		thresholdValue, err := am.toNumeric(temporal.Threshold)
+		thresholdValue, err := contract.ToNumeric(temporal.Threshold)
 		if err != nil {
 			return fmt.Errorf("cannot convert threshold to numeric: %w", err)
 		}
 
-		if !am.compareNumeric(numericValue, thresholdValue, string(temporal.Comparison)) {
+		if !compareNumeric(numericValue, thresholdValue, string(temporal.Comparison)) {
 			return fmt.Errorf("temporal validation failed: %v %s %v", numericValue, temporal.Comparison, thresholdValue)
 		}
 	}
@@ -293,157 +299,14 @@ func (am *AdvancedMatcher) validateTemporalRule(temporal contract.TemporalRule,
 
 // Helper methods for validation operations
 
-func (am *AdvancedMatcher) extractFieldValue(fieldPath string, data contract.OpenTelemetryData) interface{} {
-	parts := strings.Split(fieldPath, ".")
-	if len(parts) == 0 {
-		return nil
-	}
-
-	switch parts[0] {
-	case "span":
-		return am.extractSpanField(parts[1:], data.Traces)
-	case "metric":
-		return am.extractMetricField(parts[1:], data.Metrics)
-	case "log":
-		return am.extractLogField(parts[1:], data.Logs)
-	default:
-		return nil
-	}
-}
-
-func (am *AdvancedMatcher) extractSpanField(parts []string, traces ptrace.Traces) interface{} {
-	if traces.ResourceSpans().Len() == 0 || len(parts) == 0 {
-		return nil
-	}
-
-	resourceSpans := traces.ResourceSpans().At(0)
-	if resourceSpans.ScopeSpans().Len() == 0 {
-		return nil
-	}
-
-	scopeSpans := resourceSpans.ScopeSpans().At(0)
-	if scopeSpans.Spans().Len() == 0 {
-		return nil
-	}
-
-	span := scopeSpans.Spans().At(0)
-
-	switch parts[0] {
-	case "name":
-		return span.Name()
-	case "service", "service.name":
-		if val, ok := resourceSpans.Resource().Attributes().Get("service.name"); ok {
-			return val.Str()
-		}
-		return nil
-	case "duration":
-		return span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime()).String()
-	case "status":
-		return span.Status().Code().String()
-	case "attributes":
-		if len(parts) > 1 {
-			if val, ok := span.Attributes().Get(parts[1]); ok {
-				return contract.ValueToInterface(val)
-			}
-		}
-		return nil
-	default:
-		return nil
-	}
-}
-
-func (am *AdvancedMatcher) extractMetricField(parts []string, metrics pmetric.Metrics) interface{} {
-	if metrics.ResourceMetrics().Len() == 0 || len(parts) == 0 {
-		return nil
-	}
-
-	resourceMetrics := metrics.ResourceMetrics().At(0)
-	if resourceMetrics.ScopeMetrics().Len() == 0 {
-		return nil
-	}
-
-	scopeMetrics := resourceMetrics.ScopeMetrics().At(0)
-	if scopeMetrics.Metrics().Len() == 0 {
-		return nil
-	}
-
-	metric := scopeMetrics.Metrics().At(0)
-
-	switch parts[0] {
-	case "name":
-		return metric.Name()
-	case "type":
-		return metric.Type().String()
-	case "value":
-		// Return the first data point value
-		switch metric.Type() {
-		case pmetric.MetricTypeGauge:
-			if metric.Gauge().DataPoints().Len() > 0 {
-				return metric.Gauge().DataPoints().At(0).DoubleValue()
-			}
-		case pmetric.MetricTypeSum:
-			if metric.Sum().DataPoints().Len() > 0 {
-				return metric.Sum().DataPoints().At(0).DoubleValue()
-			}
-		}
-		return nil
-	case "labels":
-		if len(parts) > 1 {
-			switch metric.Type() {
-			case pmetric.MetricTypeGauge:
-				if metric.Gauge().DataPoints().Len() > 0 {
-					if val, ok := metric.Gauge().DataPoints().At(0).Attributes().Get(parts[1]); ok {
-						return contract.ValueToInterface(val)
-					}
-				}
-			case pmetric.MetricTypeSum:
-				if metric.Sum().DataPoints().Len() > 0 {
-					if val, ok := metric.Sum().DataPoints().At(0).Attributes().Get(parts[1]); ok {
-						return contract.ValueToInterface(val)
-					}
-				}
-			}
-		}
-		return nil
-	default:
-		return nil
-	}
-}
-
-func (am *AdvancedMatcher) extractLogField(parts []string, logs plog.Logs) interface{} {
-	if logs.ResourceLogs().Len() == 0 || len(parts) == 0 {
-		return nil
-	}
-
-	resourceLogs := logs.ResourceLogs().At(0)
-	if resourceLogs.ScopeLogs().Len() == 0 {
-		return nil
-	}
-
-	scopeLogs := resourceLogs.ScopeLogs().At(0)
-	if scopeLogs.LogRecords().Len() == 0 {
-		return nil
-	}
-
-	logRecord := scopeLogs.LogRecords().At(0)
-
-	switch parts[0] {
-	case "body":
-		return logRecord.Body().AsString()
-	case "severity":
-		return logRecord.SeverityText()
-	case "timestamp":
-		return logRecord.Timestamp().AsTime()
-	case "attributes":
-		if len(parts) > 1 {
-			if val, ok := logRecord.Attributes().Get(parts[1]); ok {
-				return contract.ValueToInterface(val)
-			}
-		}
-		return nil
-	default:
-		return nil
+// extractFieldValues returns the value of a field path for every item it reaches,
+// or a single nil when it reaches none
+func (am *AdvancedMatcher) extractFieldValues(fieldPath string, data contract.OpenTelemetryData) []interface{} {
+	values := contract.ExtractFieldValues(fieldPath, data)
+	if len(values) == 0 {
+		return []interface{}{nil}
 	}
+	return values
 }
 
 func (am *AdvancedMatcher) compareValues(a, b interface{}, operation string) bool {
@@ -469,15 +332,16 @@ func (am *AdvancedMatcher) compareValues(a, b interface{}, operation string) boo
 			}
 		}
 	case int, int64, float64:
-		aNum, _ := am.toNumeric(a)
-		bNum, _ := am.toNumeric(b)
-		return am.compareNumeric(aNum, bNum, operation)
+		aNum, _ := contract.ToNumeric(a)
+		bNum, _ := contract.ToNumeric(b)
+		return compareNumeric(aNum, bNum, operation)
 	}
 
 	return false
 }
 
-func (am *AdvancedMatcher) compareNumeric(a, b float64, operation string) bool {
+// compareNumeric compares two numbers using a comparison operation name
+func compareNumeric(a, b float64, operation string) bool {
 	switch operation {
 	case "equals":
 		return math.Abs(a-b) < 1e-9
@@ -493,21 +357,6 @@ func (am *AdvancedMatcher) compareNumeric(a, b float64, operation string) bool {
 	return false
 }
 
-func (am *AdvancedMatcher) toNumeric(value interface{}) (float64, error) {
-	switch v := value.(type) {
-	case int:
-		return float64(v), nil
-	case int64:
-		return float64(v), nil
-	case float64:
-		return v, nil
-	case string:
-		return strconv.ParseFloat(v, 64)
-	default:
-		return 0, fmt.Errorf("cannot convert %T to numeric", value)
-	}
-}
-
 func (am *AdvancedMatcher) matchesPattern(value interface{}, pattern string) bool {
 	if value == nil || pattern == "" {
 		return false
@@ -540,61 +389,6 @@ func (am *AdvancedMatcher) stringEndsWith(value, suffix interface{}) bool {
 	return strings.HasSuffix(valueStr, suffixStr)
 }
 
-func (am *AdvancedMatcher) inRange(value interface{}, valueRange contract.ValueRange) bool {
-	numValue, err := am.toNumeric(value)
-	if err != nil {
-		return false
-	}
-
-	// Check minimum
-	if valueRange.Min != nil {
-		minValue, err := am.toNumeric(valueRange.Min)
-		if err != nil {
-			return false
-		}
-
-		minInclusive := valueRange.Inclusive
-		if valueRange.MinInclusive != nil {
-			minInclusive = *valueRange.MinInclusive
-		}
-
-		if minInclusive {
-			if numValue < minValue {
-				return false
-			}
-		} else {
-			if numValue <= minValue {
-				return false
-			}
-		}
-	}
-
-	// Check maximum
-	if valueRange.Max != nil {
-		maxValue, err := am.toNumeric(valueRange.Max)
-		if err != nil {
-			return false
-		}
-
-		maxInclusive := valueRange.Inclusive
-		if valueRange.MaxInclusive != nil {
-			maxInclusive = *valueRange.MaxInclusive
-		}
-
-		if maxInclusive {
-			if numValue > maxValue {
-				return false
-			}
-		} else {
-			if numValue >= maxValue {
-				return false
-			}
-		}
-	}
-
-	return true
-}
-
 func (am *AdvancedMatcher) oneOf(value interface{}, values []interface{}) bool {
 	for _, v := range values {
 		if am.compareValues(value, v, "equals") {
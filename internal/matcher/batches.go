@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+)
+
+// validateBatches checks the number of batches the output was delivered in
+func (m *Matcher) validateBatches(matcher *contract.BatchMatcher, output contract.OpenTelemetryData) []contract.ValidationError {
+	var sizes []string
+	for _, batch := range output.Batches {
+		if matcher.Signal == "" || batch.Signal == matcher.Signal {
+			sizes = append(sizes, strconv.Itoa(batch.Items))
+		}
+	}
+
+	failure := countFailure(matcher.Count, len(sizes))
+	if failure == "" {
+		return nil
+	}
+
+	selection := "batch"
+	if matcher.Signal != "" {
+		selection = string(matcher.Signal) + " batch"
+	}
+	detail := "no batches delivered"
+	if len(sizes) > 0 {
+		detail = "batch sizes " + strings.Join(sizes, ", ")
+	}
+	return []contract.ValidationError{{
+		Type:       "batches",
+		Message:    fmt.Sprintf("batches matcher failed: %s count %s (%s)", selection, failure, detail),
+		Field:      "count",
+		Actual:     len(sizes),
+		SignalType: matcher.Signal,
+	}}
+}
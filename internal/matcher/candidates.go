@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// spanCandidate is a span together with the resource and scope it was emitted under
+type spanCandidate struct {
+	resource          pcommon.Resource
+	resourceSchemaURL string
+	scope             pcommon.InstrumentationScope
+	scopeSchemaURL    string
+	span              ptrace.Span
+	location          string
+}
+
+// metricCandidate is a metric together with the resource and scope it was emitted under
+type metricCandidate struct {
+	resource          pcommon.Resource
+	resourceSchemaURL string
+	scope             pcommon.InstrumentationScope
+	scopeSchemaURL    string
+	metric            pmetric.Metric
+	location          string
+}
+
+// logCandidate is a log record together with the resource and scope it was emitted under
+type logCandidate struct {
+	resource          pcommon.Resource
+	resourceSchemaURL string
+	scope             pcommon.InstrumentationScope
+	scopeSchemaURL    string
+	record            plog.LogRecord
+	location          string
+}
+
+// collectSpans flattens every span across all resources and scopes
+func collectSpans(traces ptrace.Traces) []spanCandidate {
+	var candidates []spanCandidate
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			scopeSpans := resourceSpans.ScopeSpans().At(j)
+			for k := 0; k < scopeSpans.Spans().Len(); k++ {
+				candidates = append(candidates, spanCandidate{
+					resource:          resourceSpans.Resource(),
+					resourceSchemaURL: resourceSpans.SchemaUrl(),
+					scope:             scopeSpans.Scope(),
+					scopeSchemaURL:    scopeSpans.SchemaUrl(),
+					span:              scopeSpans.Spans().At(k),
+					location:          fmt.Sprintf("resource %d, scope %d, span %d", i, j, k),
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+// collectMetrics flattens every metric across all resources and scopes
+func collectMetrics(metrics pmetric.Metrics) []metricCandidate {
+	var candidates []metricCandidate
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			scopeMetrics := resourceMetrics.ScopeMetrics().At(j)
+			for k := 0; k < scopeMetrics.Metrics().Len(); k++ {
+				candidates = append(candidates, metricCandidate{
+					resource:          resourceMetrics.Resource(),
+					resourceSchemaURL: resourceMetrics.SchemaUrl(),
+					scope:             scopeMetrics.Scope(),
+					scopeSchemaURL:    scopeMetrics.SchemaUrl(),
+					metric:            scopeMetrics.Metrics().At(k),
+					location:          fmt.Sprintf("resource %d, scope %d, metric %d", i, j, k),
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+// collectLogs flattens every log record across all resources and scopes
+func collectLogs(logs plog.Logs) []logCandidate {
+	var candidates []logCandidate
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			scopeLogs := resourceLogs.ScopeLogs().At(j)
+			for k := 0; k < scopeLogs.LogRecords().Len(); k++ {
+				candidates = append(candidates, logCandidate{
+					resource:          resourceLogs.Resource(),
+					resourceSchemaURL: resourceLogs.SchemaUrl(),
+					scope:             scopeLogs.Scope(),
+					scopeSchemaURL:    scopeLogs.SchemaUrl(),
+					record:            scopeLogs.LogRecords().At(k),
+					location:          fmt.Sprintf("resource %d, scope %d, log %d", i, j, k),
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+// diffContext describes the span for diffs by name and attribute maps
+func (c spanCandidate) diffContext() diffContext {
+	return diffContext{
+		identity:      "span_name",
+		identityValue: c.span.Name(),
+		maps: []fieldMap{
+			{field: "resource.attributes", attributes: c.resource.Attributes()},
+			{field: "scope.attributes", attributes: c.scope.Attributes()},
+			{field: "attributes", attributes: c.span.Attributes()},
+		},
+	}
+}
+
+// diffContext describes the metric for diffs by name and attribute maps, with the labels
+// of the data point its labels were checked against
+func (c metricCandidate) diffContext(labels pcommon.Map) diffContext {
+	return diffContext{
+		identity:      "name",
+		identityValue: c.metric.Name(),
+		maps: []fieldMap{
+			{field: "resource.attributes", attributes: c.resource.Attributes()},
+			{field: "scope.attributes", attributes: c.scope.Attributes()},
+			{field: "labels", attributes: labels},
+		},
+	}
+}
+
+// diffContext describes the log record for diffs by body and attribute maps
+func (c logCandidate) diffContext() diffContext {
+	return diffContext{
+		identity:      "body",
+		identityValue: c.record.Body().AsString(),
+		maps: []fieldMap{
+			{field: "resource.attributes", attributes: c.resource.Attributes()},
+			{field: "scope.attributes", attributes: c.scope.Attributes()},
+			{field: "attributes", attributes: c.record.Attributes()},
+		},
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+)
+
+// metricCardinality is the attribute usage of every data point of one metric name
+type metricCardinality struct {
+	dataPoints int
+	sets       map[string]bool            // Distinct attribute sets, keyed by their sorted key/value pairs
+	dimensions map[string]map[string]bool // Distinct values of each attribute
+	usage      map[string]int             // Number of data points carrying each attribute
+}
+
+// validateCardinality checks the distinct attribute sets of output metrics against
+// each cardinality matcher
+func (m *Matcher) validateCardinality(matchers []contract.CardinalityMatcher, metrics pmetric.Metrics) []contract.ValidationError {
+	cardinalities := collectCardinality(metrics)
+
+	var errors []contract.ValidationError
+	for i, matcher := range matchers {
+		for _, mm := range checkCardinality(matcher, cardinalities[matcher.Metric]) {
+			errors = append(errors, contract.ValidationError{
+				Type:       "cardinality",
+				Message:    fmt.Sprintf("cardinality matcher %d failed: %s", i, mm.message),
+				Field:      mm.field,
+				Expected:   mm.expected,
+				Actual:     mm.actual,
+				SignalType: contract.SignalTypeMetrics,
+				Index:      i,
+			})
+		}
+	}
+	return errors
+}
+
+// checkCardinality returns every bound of the matcher that the metric exceeds
+func checkCardinality(matcher contract.CardinalityMatcher, cardinality *metricCardinality) []mismatch {
+	if cardinality == nil {
+		if matcher.Max != nil && *matcher.Max == 0 {
+			return nil
+		}
+		return []mismatch{{
+			field:    "metric",
+			expected: matcher.Metric,
+			message:  fmt.Sprintf("metric %s not found in output", matcher.Metric),
+		}}
+	}
+
+	var mismatches []mismatch
+	if matcher.Max != nil && len(cardinality.sets) > *matcher.Max {
+		mismatches = append(mismatches, mismatch{
+			field:    "max",
+			expected: *matcher.Max,
+			actual:   len(cardinality.sets),
+			message: fmt.Sprintf("metric %s has %d distinct attribute sets, expected at most %d (%s)",
+				matcher.Metric, len(cardinality.sets), *matcher.Max, cardinality.describeDimensions()),
+		})
+	}
+	for _, dimension := range matcher.ForbiddenDimensions {
+		if count := cardinality.usage[dimension]; count > 0 {
+			mismatches = append(mismatches, mismatch{
+				field:    "labels." + dimension,
+				expected: nil,
+				actual:   dimension,
+				message: fmt.Sprintf("metric %s has forbidden dimension %s on %d of %d data points",
+					matcher.Metric, dimension, count, cardinality.dataPoints),
+			})
+		}
+	}
+	return mismatches
+}
+
+// describeDimensions lists each attribute with its number of distinct values,
+// highest first, so the attribute driving cardinality stands out
+func (c *metricCardinality) describeDimensions() string {
+	if len(c.dimensions) == 0 {
+		return "no attributes"
+	}
+	names := make([]string, 0, len(c.dimensions))
+	for name := range c.dimensions {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(c.dimensions[names[i]]) != len(c.dimensions[names[j]]) {
+			return len(c.dimensions[names[i]]) > len(c.dimensions[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		values := len(c.dimensions[name])
+		if values == 1 {
+			parts = append(parts, name+": 1 value")
+		} else {
+			parts = append(parts, fmt.Sprintf("%s: %d values", name, values))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// collectCardinality gathers the attribute usage of every metric in the output by
+// name, across all resources and scopes
+func collectCardinality(metrics pmetric.Metrics) map[string]*metricCardinality {
+	cardinalities := make(map[string]*metricCardinality)
+	for _, candidate := range collectMetrics(metrics) {
+		name := candidate.metric.Name()
+		cardinality, ok := cardinalities[name]
+		if !ok {
+			cardinality = &metricCardinality{
+				sets:       make(map[string]bool),
+				dimensions: make(map[string]map[string]bool),
+				usage:      make(map[string]int),
+			}
+			cardinalities[name] = cardinality
+		}
+		for _, attributes := range dataPointAttributes(candidate.metric) {
+			cardinality.add(attributes)
+		}
+	}
+	return cardinalities
+}
+
+// add records the attributes of a single data point
+func (c *metricCardinality) add(attributes pcommon.Map) {
+	c.dataPoints++
+	pairs := make([]string, 0, attributes.Len())
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		encoded := value.Type().String() + ":" + value.AsString()
+		pairs = append(pairs, fmt.Sprintf("%q=%q", key, encoded))
+		if c.dimensions[key] == nil {
+			c.dimensions[key] = make(map[string]bool)
+		}
+		c.dimensions[key][encoded] = true
+		c.usage[key]++
+		return true
+	})
+	sort.Strings(pairs)
+	c.sets[strings.Join(pairs, ",")] = true
+}
+
+// dataPointAttributes returns the attributes of every data point of a metric
+func dataPointAttributes(metric pmetric.Metric) []pcommon.Map {
+	var attributes []pcommon.Map
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+	return attributes
+}
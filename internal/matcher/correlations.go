@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+)
+
+// correlationItem is a log record or exemplar that references a span
+type correlationItem struct {
+	description string
+	traceID     pcommon.TraceID
+	spanID      pcommon.SpanID
+}
+
+// validateCorrelations checks that logs and exemplars reference spans in the output,
+// and the named input span where a correlation requires one
+func (m *Matcher) validateCorrelations(matchers []contract.CorrelationMatcher, input, output contract.OpenTelemetryData) []contract.ValidationError {
+	outputSpans := make(map[spanReference]string)
+	for _, candidate := range collectSpans(output.Traces) {
+		span := candidate.span
+		outputSpans[spanReference{traceID: span.TraceID().String(), spanID: span.SpanID().String()}] = span.Name()
+	}
+	inputSpans := collectSpanReferences(input.Traces)
+
+	var errors []contract.ValidationError
+	for i, matcher := range matchers {
+		signal := contract.SignalTypeLogs
+		if matcher.Source == contract.CorrelationSourceExemplars {
+			signal = contract.SignalTypeMetrics
+		}
+		for _, mm := range checkCorrelation(matcher, output, outputSpans, inputSpans[matcher.Span]) {
+			errors = append(errors, contract.ValidationError{
+				Type:       "correlation",
+				Message:    fmt.Sprintf("correlation %d failed: %s", i, mm.message),
+				Field:      mm.field,
+				Expected:   mm.expected,
+				Actual:     mm.actual,
+				SignalType: signal,
+				Index:      i,
+			})
+		}
+	}
+	return errors
+}
+
+// checkCorrelation returns every selected item that does not reference an expected span
+func checkCorrelation(matcher contract.CorrelationMatcher, output contract.OpenTelemetryData, outputSpans map[spanReference]string, expected []spanReference) []mismatch {
+	var items []correlationItem
+	var selection string
+	if matcher.Source == contract.CorrelationSourceExemplars {
+		items = collectExemplarItems(output.Metrics, matcher.Metric)
+		selection = "exemplars"
+		if matcher.Metric != "" {
+			selection = "exemplars on metric " + matcher.Metric
+		}
+	} else {
+		items = collectLogItems(output, matcher.Body)
+		selection = "log records"
+		if matcher.Body != "" {
+			selection = fmt.Sprintf("log records with body %q", matcher.Body)
+		}
+	}
+	if len(items) == 0 {
+		return []mismatch{{
+			field:    string(matcher.Source),
+			expected: selection,
+			message:  fmt.Sprintf("no %s found in output", selection),
+		}}
+	}
+
+	var mismatches []mismatch
+	for _, item := range items {
+		reference := spanReference{traceID: item.traceID.String(), spanID: item.spanID.String()}
+		switch {
+		case item.traceID.IsEmpty() || item.spanID.IsEmpty():
+			mismatches = append(mismatches, mismatch{
+				field:    "span",
+				expected: correlationTarget(matcher.Span),
+				message:  fmt.Sprintf("%s has no trace context", item.description),
+			})
+		case matcher.Span != "" && !containsReference(expected, reference):
+			actual := reference.traceID + "/" + reference.spanID
+			if name, ok := outputSpans[reference]; ok {
+				actual = name
+			}
+			mismatches = append(mismatches, mismatch{
+				field:    "span",
+				expected: matcher.Span,
+				actual:   actual,
+				message:  fmt.Sprintf("%s references span %s, expected input span %s", item.description, actual, matcher.Span),
+			})
+		default:
+			if _, ok := outputSpans[reference]; !ok {
+				mismatches = append(mismatches, mismatch{
+					field:    "span",
+					expected: correlationTarget(matcher.Span),
+					actual:   reference.traceID + "/" + reference.spanID,
+					message: fmt.Sprintf("%s references span %s/%s, which is not in the output",
+						item.description, reference.traceID, reference.spanID),
+				})
+			}
+		}
+	}
+	return mismatches
+}
+
+// correlationTarget describes the span a correlation expects to be referenced
+func correlationTarget(span string) string {
+	if span == "" {
+		return "output span"
+	}
+	return span
+}
+
+// collectLogItems returns the trace context of every output log record, optionally
+// only those with the given body
+func collectLogItems(output contract.OpenTelemetryData, body string) []correlationItem {
+	var items []correlationItem
+	for i, candidate := range collectLogs(output.Logs) {
+		record := candidate.record
+		if body != "" && record.Body().AsString() != body {
+			continue
+		}
+		items = append(items, correlationItem{
+			description: fmt.Sprintf("log record %d (%s)", i, candidate.location),
+			traceID:     record.TraceID(),
+			spanID:      record.SpanID(),
+		})
+	}
+	return items
+}
+
+// collectExemplarItems returns the span reference of every exemplar in the output,
+// optionally only those of the named metric
+func collectExemplarItems(metrics pmetric.Metrics, name string) []correlationItem {
+	var items []correlationItem
+	for _, candidate := range collectMetrics(metrics) {
+		metric := candidate.metric
+		if name != "" && metric.Name() != name {
+			continue
+		}
+		for i, exemplars := range dataPointExemplars(metric) {
+			for j := 0; j < exemplars.Len(); j++ {
+				exemplar := exemplars.At(j)
+				items = append(items, correlationItem{
+					description: fmt.Sprintf("exemplar %d of metric %s data point %d (%s)",
+						j, metric.Name(), i, candidate.location),
+					traceID: exemplar.TraceID(),
+					spanID:  exemplar.SpanID(),
+				})
+			}
+		}
+	}
+	return items
+}
+
+// dataPointExemplars returns the exemplars of every data point of a metric
+func dataPointExemplars(metric pmetric.Metric) []pmetric.ExemplarSlice {
+	var exemplars []pmetric.ExemplarSlice
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			exemplars = append(exemplars, metric.Gauge().DataPoints().At(i).Exemplars())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			exemplars = append(exemplars, metric.Sum().DataPoints().At(i).Exemplars())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			exemplars = append(exemplars, metric.Histogram().DataPoints().At(i).Exemplars())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			exemplars = append(exemplars, metric.ExponentialHistogram().DataPoints().At(i).Exemplars())
+		}
+	}
+	return exemplars
+}
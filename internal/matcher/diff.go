@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// diffError is a matcher failure that carries a diff against the closest candidate
+type diffError struct {
+	err  error
+	diff *contract.TelemetryDiff
+}
+
+func (e *diffError) Error() string {
+	return e.err.Error()
+}
+
+func (e *diffError) Unwrap() error {
+	return e.err
+}
+
+// withDiff attaches a diff against the candidate that explains a failure
+func withDiff(err error, candidate *candidateResult) error {
+	if err == nil || candidate == nil || candidate.context == nil {
+		return err
+	}
+	return &diffError{err: err, diff: candidate.diff()}
+}
+
+// nameDiff labels the diff carried by err with the signal and matcher it belongs to
+func nameDiff(err error, signal contract.SignalType, matcher string) error {
+	var de *diffError
+	if errors.As(err, &de) {
+		de.diff.SignalType = signal
+		de.diff.Matcher = matcher
+	}
+	return err
+}
+
+// diffOf returns the diff carried by err, if any
+func diffOf(err error) *contract.TelemetryDiff {
+	var de *diffError
+	if errors.As(err, &de) {
+		return de.diff
+	}
+	return nil
+}
+
+// fieldMap is an attribute map of a candidate together with its matcher field prefix
+type fieldMap struct {
+	field      string
+	attributes pcommon.Map
+}
+
+// diffContext lists the identifying field and attribute maps of a candidate
+type diffContext struct {
+	identity      string
+	identityValue string
+	maps          []fieldMap
+}
+
+// diff builds the diff between the matcher's expectations and the candidate. Failed
+// expectations become added, removed or changed entries; the candidate's remaining
+// attributes are included unchanged for context.
+func (r candidateResult) diff() *contract.TelemetryDiff {
+	diff := &contract.TelemetryDiff{Location: r.location}
+	failed := make(map[string]bool, len(r.mismatches))
+	for _, mm := range r.mismatches {
+		failed[mm.field] = true
+		diff.Entries = append(diff.Entries, mismatchEntry(mm))
+	}
+
+	context := r.context()
+	if context.identity != "" && !failed[context.identity] {
+		diff.Entries = append(diff.Entries, contract.DiffEntry{
+			Section: contract.DiffSectionItem,
+			Field:   context.identity,
+			Kind:    contract.DiffUnchanged,
+			Actual:  formatDiffValue(context.identityValue),
+		})
+	}
+	for _, fm := range context.maps {
+		keys := make(map[string]interface{}, fm.attributes.Len())
+		fm.attributes.Range(func(k string, _ pcommon.Value) bool {
+			keys[k] = nil
+			return true
+		})
+		for _, key := range sortedKeys(keys) {
+			field := fm.field + "." + key
+			if failed[field] {
+				continue
+			}
+			value, _ := fm.attributes.Get(key)
+			diff.Entries = append(diff.Entries, contract.DiffEntry{
+				Section: contract.DiffSectionOf(field),
+				Field:   field,
+				Kind:    contract.DiffUnchanged,
+				Actual:  formatDiffValue(contract.ValueToInterface(value)),
+			})
+		}
+	}
+
+	sort.SliceStable(diff.Entries, func(i, j int) bool {
+		return diff.Entries[i].Field < diff.Entries[j].Field
+	})
+	return diff
+}
+
+// mismatchEntry converts a failed expectation into a diff entry
+func mismatchEntry(mm mismatch) contract.DiffEntry {
+	entry := contract.DiffEntry{
+		Section: contract.DiffSectionOf(mm.field),
+		Field:   mm.field,
+		Kind:    contract.DiffChanged,
+		Message: mm.message,
+	}
+	if mm.expected != nil {
+		entry.Expected = formatDiffValue(mm.expected)
+	}
+	if mm.actual != nil {
+		entry.Actual = formatDiffValue(mm.actual)
+	}
+	switch {
+	case mm.expected != nil && mm.actual == nil:
+		entry.Kind = contract.DiffRemoved
+	case mm.expected == nil && mm.actual != nil:
+		entry.Kind = contract.DiffAdded
+	}
+	return entry
+}
+
+// formatDiffValue formats an expected or actual value for a diff entry
+func formatDiffValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,363 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Outcomes of an input item after it passed through the pipeline
+const (
+	outcomeDropped   = "dropped"
+	outcomeUnchanged = "unchanged"
+	outcomeModified  = "modified"
+)
+
+// inputOutcome is what happened to a single input item, correlated from the output
+type inputOutcome struct {
+	label   string
+	outcome string
+	changes []string
+}
+
+// validateExpectations correlates every input item that has an expected outcome with
+// the output and reports each input whose outcome differs from its expectation
+func (m *Matcher) validateExpectations(contractDef *contract.Contract, input, output contract.OpenTelemetryData) []contract.ValidationError {
+	var errors []contract.ValidationError
+
+	if modes := contractDef.InputExpectations(contract.SignalTypeTraces); hasExpectations(modes) {
+		outcomes := m.traceOutcomes(collectSpans(input.Traces), collectSpans(output.Traces))
+		errors = append(errors, expectationErrors(contract.SignalTypeTraces, "trace", modes, outcomes)...)
+	}
+	if modes := contractDef.InputExpectations(contract.SignalTypeMetrics); hasExpectations(modes) {
+		outcomes := m.metricOutcomes(collectMetrics(input.Metrics), collectMetrics(output.Metrics))
+		errors = append(errors, expectationErrors(contract.SignalTypeMetrics, "metric", modes, outcomes)...)
+	}
+	if modes := contractDef.InputExpectations(contract.SignalTypeLogs); hasExpectations(modes) {
+		outcomes := m.logOutcomes(collectLogs(input.Logs), collectLogs(output.Logs))
+		errors = append(errors, expectationErrors(contract.SignalTypeLogs, "log", modes, outcomes)...)
+	}
+
+	return errors
+}
+
+// hasExpectations reports whether any input item has an expected outcome
+func hasExpectations(modes []contract.ExpectMode) bool {
+	for _, mode := range modes {
+		if mode != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// expectationErrors compares each input's expected mode with its observed outcome.
+// Inputs are generated one item per contract input, so outcome i belongs to input i.
+func expectationErrors(signal contract.SignalType, kind string, modes []contract.ExpectMode, outcomes []inputOutcome) []contract.ValidationError {
+	var errors []contract.ValidationError
+	for i, mode := range modes {
+		if mode == "" {
+			continue
+		}
+		field := fmt.Sprintf("inputs.%s[%d]", signal, i)
+		if i >= len(outcomes) {
+			errors = append(errors, contract.ValidationError{
+				Type:       "expectation",
+				Message:    fmt.Sprintf("%s input %d: not found in generated input data", kind, i),
+				Field:      field,
+				Expected:   string(mode),
+				SignalType: signal,
+				Index:      i,
+			})
+			continue
+		}
+
+		outcome := outcomes[i]
+		var failed bool
+		switch mode {
+		case contract.ExpectDropped:
+			failed = outcome.outcome != outcomeDropped
+		case contract.ExpectPassedThrough:
+			failed = outcome.outcome == outcomeDropped
+		case contract.ExpectUnchanged:
+			failed = outcome.outcome != outcomeUnchanged
+		}
+		if !failed {
+			continue
+		}
+
+		message := fmt.Sprintf("%s input %d (%s): expected %s, but it was %s", kind, i, outcome.label, mode, outcome.outcome)
+		if outcome.outcome == outcomeModified && mode == contract.ExpectUnchanged {
+			message += ": " + strings.Join(outcome.changes, "; ")
+		}
+		errors = append(errors, contract.ValidationError{
+			Type:       "expectation",
+			Message:    message,
+			Field:      field,
+			Expected:   string(mode),
+			Actual:     outcome.outcome,
+			SignalType: signal,
+			Index:      i,
+		})
+	}
+	return errors
+}
+
+// traceOutcomes correlates input spans with output spans by trace and span ID
+func (m *Matcher) traceOutcomes(inputs, outputs []spanCandidate) []inputOutcome {
+	byID := make(map[spanReference]spanCandidate, len(outputs))
+	for _, candidate := range outputs {
+		reference := spanReference{traceID: candidate.span.TraceID().String(), spanID: candidate.span.SpanID().String()}
+		if _, ok := byID[reference]; !ok {
+			byID[reference] = candidate
+		}
+	}
+
+	outcomes := make([]inputOutcome, 0, len(inputs))
+	for _, in := range inputs {
+		reference := spanReference{traceID: in.span.TraceID().String(), spanID: in.span.SpanID().String()}
+		label := "span " + in.span.Name()
+		out, ok := byID[reference]
+		if !ok {
+			outcomes = append(outcomes, inputOutcome{label: label, outcome: outcomeDropped})
+			continue
+		}
+		outcomes = append(outcomes, changedOutcome(label, m.spanChanges(in, out)))
+	}
+	return outcomes
+}
+
+// metricOutcomes correlates input metrics with output metrics by name. The nth input
+// with a given name corresponds to the nth output metric with that name.
+func (m *Matcher) metricOutcomes(inputs, outputs []metricCandidate) []inputOutcome {
+	byName := make(map[string][]metricCandidate)
+	for _, candidate := range outputs {
+		name := candidate.metric.Name()
+		byName[name] = append(byName[name], candidate)
+	}
+
+	seen := make(map[string]int)
+	outcomes := make([]inputOutcome, 0, len(inputs))
+	for _, in := range inputs {
+		name := in.metric.Name()
+		label := "metric " + name
+		occurrence := seen[name]
+		seen[name]++
+		if occurrence >= len(byName[name]) {
+			outcomes = append(outcomes, inputOutcome{label: label, outcome: outcomeDropped})
+			continue
+		}
+		outcomes = append(outcomes, changedOutcome(label, m.metricChanges(in, byName[name][occurrence])))
+	}
+	return outcomes
+}
+
+// logOutcomes correlates input logs with output logs by trace and span ID, falling back
+// to the body for records without IDs. Repeated keys correlate in order.
+func (m *Matcher) logOutcomes(inputs, outputs []logCandidate) []inputOutcome {
+	byKey := make(map[string][]logCandidate)
+	for _, candidate := range outputs {
+		key := logKey(candidate)
+		byKey[key] = append(byKey[key], candidate)
+	}
+
+	seen := make(map[string]int)
+	outcomes := make([]inputOutcome, 0, len(inputs))
+	for _, in := range inputs {
+		key := logKey(in)
+		label := fmt.Sprintf("log %q", in.record.Body().AsString())
+		occurrence := seen[key]
+		seen[key]++
+		if occurrence >= len(byKey[key]) {
+			outcomes = append(outcomes, inputOutcome{label: label, outcome: outcomeDropped})
+			continue
+		}
+		outcomes = append(outcomes, changedOutcome(label, m.logChanges(in, byKey[key][occurrence])))
+	}
+	return outcomes
+}
+
+// logKey identifies a log record for correlation
+func logKey(candidate logCandidate) string {
+	record := candidate.record
+	if !record.TraceID().IsEmpty() && !record.SpanID().IsEmpty() {
+		return "id:" + record.TraceID().String() + "/" + record.SpanID().String()
+	}
+	return "body:" + record.Body().AsString()
+}
+
+// changedOutcome is the outcome of an input found in the output with the given changes
+func changedOutcome(label string, changes []string) inputOutcome {
+	if len(changes) == 0 {
+		return inputOutcome{label: label, outcome: outcomeUnchanged}
+	}
+	return inputOutcome{label: label, outcome: outcomeModified, changes: changes}
+}
+
+// spanChanges lists the differences between an input span and its output counterpart
+func (m *Matcher) spanChanges(in, out spanCandidate) []string {
+	var changes []string
+	changes = appendChange(changes, "name", in.span.Name(), out.span.Name())
+	changes = appendChange(changes, "kind", in.span.Kind().String(), out.span.Kind().String())
+	changes = appendChange(changes, "parent_span_id", in.span.ParentSpanID().String(), out.span.ParentSpanID().String())
+	changes = appendChange(changes, "status.code", in.span.Status().Code().String(), out.span.Status().Code().String())
+	changes = appendChange(changes, "status.message", in.span.Status().Message(), out.span.Status().Message())
+	if !m.ignoreTimestamps {
+		changes = appendChange(changes, "start_time", in.span.StartTimestamp().String(), out.span.StartTimestamp().String())
+		changes = appendChange(changes, "end_time", in.span.EndTimestamp().String(), out.span.EndTimestamp().String())
+	}
+	changes = append(changes, mapChanges("attributes", in.span.Attributes(), out.span.Attributes())...)
+	changes = append(changes, mapChanges("resource.attributes", in.resource.Attributes(), out.resource.Attributes())...)
+	changes = append(changes, scopeChanges(in.scope, out.scope)...)
+
+	inEvents, outEvents := in.span.Events(), out.span.Events()
+	if inEvents.Len() != outEvents.Len() {
+		changes = append(changes, fmt.Sprintf("events: %d -> %d", inEvents.Len(), outEvents.Len()))
+	} else {
+		for i := 0; i < inEvents.Len(); i++ {
+			field := fmt.Sprintf("events[%d]", i)
+			changes = appendChange(changes, field+".name", inEvents.At(i).Name(), outEvents.At(i).Name())
+			changes = append(changes, mapChanges(field+".attributes", inEvents.At(i).Attributes(), outEvents.At(i).Attributes())...)
+		}
+	}
+
+	inLinks, outLinks := in.span.Links(), out.span.Links()
+	if inLinks.Len() != outLinks.Len() {
+		changes = append(changes, fmt.Sprintf("links: %d -> %d", inLinks.Len(), outLinks.Len()))
+	} else {
+		for i := 0; i < inLinks.Len(); i++ {
+			field := fmt.Sprintf("links[%d]", i)
+			changes = appendChange(changes, field+".span_id", inLinks.At(i).SpanID().String(), outLinks.At(i).SpanID().String())
+			changes = append(changes, mapChanges(field+".attributes", inLinks.At(i).Attributes(), outLinks.At(i).Attributes())...)
+		}
+	}
+	return changes
+}
+
+// metricChanges lists the differences between an input metric and its output counterpart
+func (m *Matcher) metricChanges(in, out metricCandidate) []string {
+	var changes []string
+	changes = appendChange(changes, "type", contract.MetricTypeName(in.metric), contract.MetricTypeName(out.metric))
+	changes = appendChange(changes, "unit", in.metric.Unit(), out.metric.Unit())
+	changes = appendChange(changes, "description", in.metric.Description(), out.metric.Description())
+	changes = append(changes, mapChanges("resource.attributes", in.resource.Attributes(), out.resource.Attributes())...)
+	changes = append(changes, scopeChanges(in.scope, out.scope)...)
+	if in.metric.Type() != out.metric.Type() {
+		return changes
+	}
+
+	switch in.metric.Type() {
+	case pmetric.MetricTypeGauge:
+		changes = append(changes, numberPointChanges(in.metric.Gauge().DataPoints(), out.metric.Gauge().DataPoints())...)
+	case pmetric.MetricTypeSum:
+		changes = append(changes, numberPointChanges(in.metric.Sum().DataPoints(), out.metric.Sum().DataPoints())...)
+	case pmetric.MetricTypeHistogram:
+		inPoints, outPoints := in.metric.Histogram().DataPoints(), out.metric.Histogram().DataPoints()
+		if inPoints.Len() != outPoints.Len() {
+			return append(changes, fmt.Sprintf("data_points: %d -> %d", inPoints.Len(), outPoints.Len()))
+		}
+		for i := 0; i < inPoints.Len(); i++ {
+			field := fmt.Sprintf("data_points[%d]", i)
+			inPoint, outPoint := inPoints.At(i), outPoints.At(i)
+			changes = append(changes, mapChanges(field+".attributes", inPoint.Attributes(), outPoint.Attributes())...)
+			changes = appendChange(changes, field+".count", fmt.Sprint(inPoint.Count()), fmt.Sprint(outPoint.Count()))
+			changes = appendChange(changes, field+".sum", fmt.Sprint(inPoint.Sum()), fmt.Sprint(outPoint.Sum()))
+			changes = appendChange(changes, field+".bucket_counts",
+				fmt.Sprint(inPoint.BucketCounts().AsRaw()), fmt.Sprint(outPoint.BucketCounts().AsRaw()))
+		}
+	}
+	return changes
+}
+
+// numberPointChanges lists the differences between gauge or sum data points
+func numberPointChanges(inPoints, outPoints pmetric.NumberDataPointSlice) []string {
+	if inPoints.Len() != outPoints.Len() {
+		return []string{fmt.Sprintf("data_points: %d -> %d", inPoints.Len(), outPoints.Len())}
+	}
+	var changes []string
+	for i := 0; i < inPoints.Len(); i++ {
+		field := fmt.Sprintf("data_points[%d]", i)
+		inPoint, outPoint := inPoints.At(i), outPoints.At(i)
+		changes = append(changes, mapChanges(field+".attributes", inPoint.Attributes(), outPoint.Attributes())...)
+		changes = appendChange(changes, field+".value", numberPointValue(inPoint), numberPointValue(outPoint))
+	}
+	return changes
+}
+
+// numberPointValue formats a number data point's value
+func numberPointValue(point pmetric.NumberDataPoint) string {
+	if point.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return fmt.Sprintf("int %d", point.IntValue())
+	}
+	return fmt.Sprintf("double %v", point.DoubleValue())
+}
+
+// logChanges lists the differences between an input log record and its output counterpart
+func (m *Matcher) logChanges(in, out logCandidate) []string {
+	var changes []string
+	if !in.record.Body().Equal(out.record.Body()) {
+		changes = append(changes, fmt.Sprintf("body: %s -> %s",
+			contract.DescribeValue(in.record.Body()), contract.DescribeValue(out.record.Body())))
+	}
+	changes = appendChange(changes, "severity_text", in.record.SeverityText(), out.record.SeverityText())
+	changes = appendChange(changes, "severity_number", in.record.SeverityNumber().String(), out.record.SeverityNumber().String())
+	if !m.ignoreTimestamps {
+		changes = appendChange(changes, "timestamp", in.record.Timestamp().String(), out.record.Timestamp().String())
+	}
+	changes = append(changes, mapChanges("attributes", in.record.Attributes(), out.record.Attributes())...)
+	changes = append(changes, mapChanges("resource.attributes", in.resource.Attributes(), out.resource.Attributes())...)
+	changes = append(changes, scopeChanges(in.scope, out.scope)...)
+	return changes
+}
+
+// scopeChanges lists the differences between two instrumentation scopes
+func scopeChanges(in, out pcommon.InstrumentationScope) []string {
+	var changes []string
+	changes = appendChange(changes, "scope.name", in.Name(), out.Name())
+	changes = appendChange(changes, "scope.version", in.Version(), out.Version())
+	changes = append(changes, mapChanges("scope.attributes", in.Attributes(), out.Attributes())...)
+	return changes
+}
+
+// mapChanges lists keys added, removed or changed between two attribute maps
+func mapChanges(field string, in, out pcommon.Map) []string {
+	var changes []string
+	keys := make(map[string]interface{})
+	in.Range(func(k string, _ pcommon.Value) bool {
+		keys[k] = nil
+		return true
+	})
+	out.Range(func(k string, _ pcommon.Value) bool {
+		keys[k] = nil
+		return true
+	})
+
+	for _, key := range sortedKeys(keys) {
+		inValue, inFound := in.Get(key)
+		outValue, outFound := out.Get(key)
+		switch {
+		case !inFound:
+			changes = append(changes, fmt.Sprintf("%s.%s added: %s", field, key, contract.DescribeValue(outValue)))
+		case !outFound:
+			changes = append(changes, fmt.Sprintf("%s.%s removed", field, key))
+		case !inValue.Equal(outValue):
+			changes = append(changes, fmt.Sprintf("%s.%s: %s -> %s", field, key,
+				contract.DescribeValue(inValue), contract.DescribeValue(outValue)))
+		}
+	}
+	return changes
+}
+
+// appendChange records a field whose value differs between input and output
+func appendChange(changes []string, field, in, out string) []string {
+	if in == out {
+		return changes
+	}
+	return append(changes, fmt.Sprintf("%s: %q -> %q", field, in, out))
+}
@@ -4,8 +4,9 @@
 package matcher
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +21,8 @@ import (
 type Matcher struct {
 	ignoreTimestamps bool
 	timeTolerance    time.Duration
+	comparison       contract.CompareOptions
+	inputSpans       map[string][]spanReference
 }
 
 // NewMatcher creates a new matcher instance
@@ -40,6 +43,11 @@ func (m *Matcher) SetTimeTolerance(tolerance time.Duration) {
 	m.timeTolerance = tolerance
 }
 
+// SetCompareOptions sets the default options for comparing attribute values
+func (m *Matcher) SetCompareOptions(options contract.CompareOptions) {
+	m.comparison = options
+}
+
 // Validate validates output data against contract expectations
 func (m *Matcher) Validate(contractDef *contract.Contract, input, output contract.OpenTelemetryData) contract.ValidationResult {
 	result := contract.ValidationResult{
@@ -49,19 +57,48 @@ func (m *Matcher) Validate(contractDef *contract.Contract, input, output contrac
 	}
 
 	// Apply filters to determine if this contract should be validated
-	if !m.applyFilters(contractDef.Filters, input) {
+	applies, reason, err := m.applyFilters(contractDef.Filters, input)
+	if err != nil {
+		result.Valid = false
+		result.Errors = append(result.Errors, contract.ValidationError{
+			Type:    "filter",
+			Message: err.Error(),
+		})
+		return result
+	}
+	if !applies {
+		result.Skipped = true
+		result.SkipReason = reason
 		return result
 	}
 
+	// Scope the matcher to this contract: its comparison options and the named
+	// input spans that span links are resolved against
+	scoped := *m
+	if contractDef.Matchers.Comparison != nil {
+		scoped.comparison = *contractDef.Matchers.Comparison
+	}
+	for _, traceMatcher := range contractDef.Matchers.Traces {
+		if len(traceMatcher.Links) > 0 {
+			scoped.inputSpans = collectSpanReferences(input.Traces)
+			break
+		}
+	}
+	m = &scoped
+
 	// Validate traces
 	if len(contractDef.Matchers.Traces) > 0 {
 		if err := m.validateTraces(contractDef.Matchers.Traces, output.Traces); err != nil {
 			result.Valid = false
-			result.Errors = append(result.Errors, contract.ValidationError{
-				Type:       "trace_validation",
-				Message:    err.Error(),
-				SignalType: contract.SignalTypeTraces,
-			})
+			result.Errors = append(result.Errors, validationErrors("trace_validation", contract.SignalTypeTraces, err)...)
+		}
+	}
+
+	// Validate trace structure
+	if contractDef.Matchers.TraceStructure != nil {
+		if err := m.validateTraceStructure(contractDef.Matchers.TraceStructure, output.Traces); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, validationErrors("trace_structure", contract.SignalTypeTraces, err)...)
 		}
 	}
 
@@ -69,11 +106,7 @@ func (m *Matcher) Validate(contractDef *contract.Contract, input, output contrac
 	if len(contractDef.Matchers.Metrics) > 0 {
 		if err := m.validateMetrics(contractDef.Matchers.Metrics, output.Metrics); err != nil {
 			result.Valid = false
-			result.Errors = append(result.Errors, contract.ValidationError{
-				Type:       "metric_validation",
-				Message:    err.Error(),
-				SignalType: contract.SignalTypeMetrics,
-			})
+			result.Errors = append(result.Errors, validationErrors("metric_validation", contract.SignalTypeMetrics, err)...)
 		}
 	}
 
@@ -81,605 +114,440 @@ func (m *Matcher) Validate(contractDef *contract.Contract, input, output contrac
 	if len(contractDef.Matchers.Logs) > 0 {
 		if err := m.validateLogs(contractDef.Matchers.Logs, output.Logs); err != nil {
 			result.Valid = false
-			result.Errors = append(result.Errors, contract.ValidationError{
-				Type:       "log_validation",
-				Message:    err.Error(),
-				SignalType: contract.SignalTypeLogs,
-			})
+			result.Errors = append(result.Errors, validationErrors("log_validation", contract.SignalTypeLogs, err)...)
 		}
 	}
 
-	return result
-}
-
-// applyFilters applies filter predicates to determine if a contract should be validated
-func (m *Matcher) applyFilters(filters []contract.Filter, data contract.OpenTelemetryData) bool {
-	if len(filters) == 0 {
-		return true
-	}
-
-	for _, filter := range filters {
-		if !m.evaluateFilter(filter, data) {
-			return false
+	// Check references from logs and exemplars to spans
+	if len(contractDef.Matchers.Correlations) > 0 {
+		if errors := m.validateCorrelations(contractDef.Matchers.Correlations, input, output); len(errors) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors...)
 		}
 	}
-	return true
-}
-
-// evaluateFilter evaluates a single filter against the data
-func (m *Matcher) evaluateFilter(filter contract.Filter, data contract.OpenTelemetryData) bool {
-	// Extract the field value based on the field path
-	fieldValue := m.extractFieldValue(filter.Field, data)
-
-	switch filter.Operator {
-	case contract.FilterOperatorEquals:
-		return m.compareValues(fieldValue, filter.Value, "equals")
-	case contract.FilterOperatorNotEquals:
-		return !m.compareValues(fieldValue, filter.Value, "equals")
-	case contract.FilterOperatorMatches:
-		return m.matchesPattern(fieldValue, filter.Value)
-	case contract.FilterOperatorNotMatches:
-		return !m.matchesPattern(fieldValue, filter.Value)
-	case contract.FilterOperatorExists:
-		return fieldValue != nil
-	case contract.FilterOperatorNotExists:
-		return fieldValue == nil
-	case contract.FilterOperatorGreaterThan:
-		return m.compareValues(fieldValue, filter.Value, "greater_than")
-	case contract.FilterOperatorLessThan:
-		return m.compareValues(fieldValue, filter.Value, "less_than")
-	case contract.FilterOperatorGreaterOrEqual:
-		return m.compareValues(fieldValue, filter.Value, "greater_or_equal")
-	case contract.FilterOperatorLessOrEqual:
-		return m.compareValues(fieldValue, filter.Value, "less_or_equal")
-	case contract.FilterOperatorContains:
-		return m.containsValue(fieldValue, filter.Value)
-	case contract.FilterOperatorNotContains:
-		return !m.containsValue(fieldValue, filter.Value)
-	case contract.FilterOperatorStartsWith:
-		return m.startsWithValue(fieldValue, filter.Value)
-	case contract.FilterOperatorEndsWith:
-		return m.endsWithValue(fieldValue, filter.Value)
-	default:
-		return false
-	}
-}
-
-// extractFieldValue extracts a field value from the data based on a dot-separated path
-func (m *Matcher) extractFieldValue(fieldPath string, data contract.OpenTelemetryData) interface{} {
-	parts := strings.Split(fieldPath, ".")
-	if len(parts) == 0 {
-		return nil
-	}
-
-	switch parts[0] {
-	case "span":
-		return m.extractSpanField(parts[1:], data.Traces)
-	case "metric":
-		return m.extractMetricField(parts[1:], data.Metrics)
-	case "log":
-		return m.extractLogField(parts[1:], data.Logs)
-	default:
-		return nil
-	}
-}
-
-// extractSpanField extracts a field from span data
-func (m *Matcher) extractSpanField(parts []string, traces ptrace.Traces) interface{} {
-	if traces.ResourceSpans().Len() == 0 {
-		return nil
-	}
-
-	// For simplicity, we'll look at the first span
-	resourceSpans := traces.ResourceSpans().At(0)
-	if resourceSpans.ScopeSpans().Len() == 0 {
-		return nil
-	}
 
-	scopeSpans := resourceSpans.ScopeSpans().At(0)
-	if scopeSpans.Spans().Len() == 0 {
-		return nil
-	}
-
-	span := scopeSpans.Spans().At(0)
-
-	if len(parts) == 0 {
-		return nil
-	}
-
-	switch parts[0] {
-	case "name":
-		return span.Name()
-	case "service", "service.name":
-		if val, ok := resourceSpans.Resource().Attributes().Get("service.name"); ok {
-			return val.Str()
-		}
-		return ""
-	case "attributes":
-		if len(parts) > 1 {
-			if val, ok := span.Attributes().Get(parts[1]); ok {
-				return val.AsString()
-			}
+	// Check metric cardinality bounds
+	if len(contractDef.Matchers.Cardinality) > 0 {
+		if errors := m.validateCardinality(contractDef.Matchers.Cardinality, output.Metrics); len(errors) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors...)
 		}
-		return nil
-	default:
-		return nil
 	}
-}
 
-// extractMetricField extracts a field from metric data
-func (m *Matcher) extractMetricField(parts []string, metrics pmetric.Metrics) interface{} {
-	if metrics.ResourceMetrics().Len() == 0 {
-		return nil
-	}
-
-	resourceMetrics := metrics.ResourceMetrics().At(0)
-	if resourceMetrics.ScopeMetrics().Len() == 0 {
-		return nil
+	// Check how the output was split into batches
+	if contractDef.Matchers.Batches != nil {
+		if errors := m.validateBatches(contractDef.Matchers.Batches, output); len(errors) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors...)
+		}
 	}
 
-	scopeMetrics := resourceMetrics.ScopeMetrics().At(0)
-	if scopeMetrics.Metrics().Len() == 0 {
-		return nil
+	// Check semantic convention conformance
+	if contractDef.Matchers.Semconv != nil {
+		if errors := m.validateSemconv(contractDef.Matchers.Semconv, output); len(errors) > 0 {
+			result.Valid = false
+			result.Errors = append(result.Errors, errors...)
+		}
 	}
 
-	metric := scopeMetrics.Metrics().At(0)
-
-	if len(parts) == 0 {
-		return nil
+	// Validate the expected outcome of each input item
+	if errors := m.validateExpectations(contractDef, input, output); len(errors) > 0 {
+		result.Valid = false
+		result.Errors = append(result.Errors, errors...)
 	}
 
-	switch parts[0] {
-	case "name":
-		return metric.Name()
-	case "type":
-		switch metric.Type() {
-		case pmetric.MetricTypeGauge:
-			return "gauge"
-		case pmetric.MetricTypeSum:
-			// Check if it's a counter (monotonic sum) or regular sum
-			if metric.Sum().IsMonotonic() {
-				return "counter"
-			}
-			return "sum"
-		case pmetric.MetricTypeHistogram:
-			return "histogram"
-		default:
-			return "unknown"
-		}
-	case "labels":
-		if len(parts) > 1 {
-			// For simplicity, we'll look at the first data point
-			switch metric.Type() {
-			case pmetric.MetricTypeGauge:
-				if metric.Gauge().DataPoints().Len() > 0 {
-					if val, ok := metric.Gauge().DataPoints().At(0).Attributes().Get(parts[1]); ok {
-						return val.AsString()
-					}
-				}
-			case pmetric.MetricTypeSum:
-				if metric.Sum().DataPoints().Len() > 0 {
-					if val, ok := metric.Sum().DataPoints().At(0).Attributes().Get(parts[1]); ok {
-						return val.AsString()
-					}
-				}
-			}
-		}
-		return nil
-	default:
-		return nil
-	}
+	return result
 }
 
-// extractLogField extracts a field from log data
-func (m *Matcher) extractLogField(parts []string, logs plog.Logs) interface{} {
-	if logs.ResourceLogs().Len() == 0 {
-		return nil
-	}
-
-	resourceLogs := logs.ResourceLogs().At(0)
-	if resourceLogs.ScopeLogs().Len() == 0 {
-		return nil
-	}
+// matcherError is the failure of a single trace, metric or log matcher
+type matcherError struct {
+	kind  string
+	index int
+	err   error
+}
 
-	scopeLogs := resourceLogs.ScopeLogs().At(0)
-	if scopeLogs.LogRecords().Len() == 0 {
-		return nil
-	}
+// newMatcherError wraps a matcher failure and labels any diff it carries
+func newMatcherError(signal contract.SignalType, kind string, index int, err error) error {
+	return nameDiff(&matcherError{kind: kind, index: index, err: err}, signal, fmt.Sprintf("%s matcher %d", kind, index))
+}
 
-	logRecord := scopeLogs.LogRecords().At(0)
+func (e *matcherError) Error() string {
+	return fmt.Sprintf("%s matcher %d failed: %v", e.kind, e.index, e.err)
+}
 
-	if len(parts) == 0 {
-		return nil
-	}
+func (e *matcherError) Unwrap() error {
+	return e.err
+}
 
-	switch parts[0] {
-	case "body":
-		return logRecord.Body().AsString()
-	case "severity":
-		return logRecord.SeverityText()
-	case "attributes":
-		if len(parts) > 1 {
-			if val, ok := logRecord.Attributes().Get(parts[1]); ok {
-				return val.AsString()
-			}
+// validationErrors splits a validation failure into one error per failed expectation.
+// Matcher failures explained by a candidate yield an error for each of its mismatches;
+// the diff against the candidate is attached to the first of them.
+func validationErrors(errorType string, signal contract.SignalType, err error) []contract.ValidationError {
+	failures := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		failures = joined.Unwrap()
+	}
+
+	var result []contract.ValidationError
+	for i, failure := range failures {
+		index, prefix := i, ""
+		var me *matcherError
+		if errors.As(failure, &me) {
+			index, prefix = me.index, fmt.Sprintf("%s matcher %d failed: ", me.kind, me.index)
 		}
-		return nil
-	default:
-		return nil
-	}
-}
 
-// compareValues compares two values for equality or ordering
-func (m *Matcher) compareValues(a, b interface{}, operation string) bool {
-	if a == nil || b == nil {
-		return a == b
-	}
-
-	// Try to convert to comparable types
-	switch av := a.(type) {
-	case string:
-		if bv, ok := b.(string); ok {
-			switch operation {
-			case "equals":
-				return av == bv
-			case "greater_than":
-				return av > bv
-			case "less_than":
-				return av < bv
-			case "greater_or_equal":
-				return av >= bv
-			case "less_or_equal":
-				return av <= bv
+		var ce *candidateError
+		if errors.As(failure, &ce) && len(ce.candidate.mismatches) > 0 {
+			diff := diffOf(failure)
+			for j, mm := range ce.candidate.mismatches {
+				validationError := contract.ValidationError{
+					Type:       errorType,
+					Message:    prefix + ce.headline + ": " + mm.message,
+					Field:      mm.field,
+					Expected:   mm.expected,
+					Actual:     mm.actual,
+					SignalType: signal,
+					Index:      index,
+				}
+				if j == 0 {
+					validationError.Diff = diff
+				}
+				result = append(result, validationError)
 			}
-		}
-	case int, int64:
-		var avInt int64
-		switch v := av.(type) {
-		case int:
-			avInt = int64(v)
-		case int64:
-			avInt = v
+			continue
 		}
 
-		switch bv := b.(type) {
-		case int:
-			switch operation {
-			case "equals":
-				return avInt == int64(bv)
-			case "greater_than":
-				return avInt > int64(bv)
-			case "less_than":
-				return avInt < int64(bv)
-			case "greater_or_equal":
-				return avInt >= int64(bv)
-			case "less_or_equal":
-				return avInt <= int64(bv)
-			}
-		case int64:
-			switch operation {
-			case "equals":
-				return avInt == bv
-			case "greater_than":
-				return avInt > bv
-			case "less_than":
-				return avInt < bv
-			case "greater_or_equal":
-				return avInt >= bv
-			case "less_or_equal":
-				return avInt <= bv
-			}
+		validationError := contract.ValidationError{
+			Type:       errorType,
+			Message:    failure.Error(),
+			SignalType: signal,
+			Index:      index,
+			Diff:       diffOf(failure),
 		}
-	case float64:
-		if bv, ok := b.(float64); ok {
-			switch operation {
-			case "equals":
-				return av == bv
-			case "greater_than":
-				return av > bv
-			case "less_than":
-				return av < bv
-			case "greater_or_equal":
-				return av >= bv
-			case "less_or_equal":
-				return av <= bv
-			}
+		var mm mismatch
+		if errors.As(failure, &mm) {
+			validationError.Field, validationError.Expected, validationError.Actual = mm.field, mm.expected, mm.actual
 		}
+		result = append(result, validationError)
 	}
-
-	return false
-}
-
-// matchesPattern checks if a value matches a regex pattern
-func (m *Matcher) matchesPattern(value interface{}, pattern interface{}) bool {
-	if value == nil || pattern == nil {
-		return false
-	}
-
-	valueStr, ok := value.(string)
-	if !ok {
-		valueStr = fmt.Sprintf("%v", value)
-	}
-
-	patternStr, ok := pattern.(string)
-	if !ok {
-		return false
-	}
-
-	regex, err := regexp.Compile(patternStr)
-	if err != nil {
-		return false
-	}
-
-	return regex.MatchString(valueStr)
-}
-
-// containsValue checks if a value contains another value
-func (m *Matcher) containsValue(value interface{}, contains interface{}) bool {
-	if value == nil || contains == nil {
-		return false
-	}
-
-	valueStr, ok := value.(string)
-	if !ok {
-		valueStr = fmt.Sprintf("%v", value)
-	}
-
-	containsStr, ok := contains.(string)
-	if !ok {
-		containsStr = fmt.Sprintf("%v", contains)
-	}
-
-	return strings.Contains(valueStr, containsStr)
-}
-
-// startsWithValue checks if a value starts with another value
-func (m *Matcher) startsWithValue(value interface{}, startsWith interface{}) bool {
-	if value == nil || startsWith == nil {
-		return false
-	}
-
-	valueStr, ok := value.(string)
-	if !ok {
-		valueStr = fmt.Sprintf("%v", value)
-	}
-
-	startsWithStr, ok := startsWith.(string)
-	if !ok {
-		startsWithStr = fmt.Sprintf("%v", startsWith)
-	}
-
-	return strings.HasPrefix(valueStr, startsWithStr)
+	return result
 }
 
-// endsWithValue checks if a value ends with another value
-func (m *Matcher) endsWithValue(value interface{}, endsWith interface{}) bool {
-	if value == nil || endsWith == nil {
-		return false
-	}
-
-	valueStr, ok := value.(string)
-	if !ok {
-		valueStr = fmt.Sprintf("%v", value)
-	}
-
-	endsWithStr, ok := endsWith.(string)
-	if !ok {
-		endsWithStr = fmt.Sprintf("%v", endsWith)
+// applyFilters applies filter predicates to determine if a contract should be validated
+func (m *Matcher) applyFilters(filters []contract.Filter, data contract.OpenTelemetryData) (bool, string, error) {
+	if len(filters) == 0 {
+		return true, "", nil
 	}
-
-	return strings.HasSuffix(valueStr, endsWithStr)
+	return contract.ApplyFilters(filters, data)
 }
 
 // validateTraces validates trace data against matchers
 func (m *Matcher) validateTraces(matchers []contract.TraceMatcher, traces ptrace.Traces) error {
-	if traces.ResourceSpans().Len() == 0 {
-		return fmt.Errorf("no traces found in output")
-	}
+	candidates := collectSpans(traces)
 
+	var failures []error
 	for i, matcher := range matchers {
-		if err := m.validateTrace(matcher, traces); err != nil {
-			return fmt.Errorf("trace matcher %d failed: %w", i, err)
+		if err := m.validateTrace(matcher, candidates); err != nil {
+			failures = append(failures, newMatcherError(contract.SignalTypeTraces, "trace", i, err))
 		}
 	}
 
-	return nil
+	return errors.Join(failures...)
 }
 
-// validateTrace validates a single trace against a matcher
-func (m *Matcher) validateTrace(matcher contract.TraceMatcher, traces ptrace.Traces) error {
-	// For simplicity, we'll validate against the first span
-	resourceSpans := traces.ResourceSpans().At(0)
-	scopeSpans := resourceSpans.ScopeSpans().At(0)
-	span := scopeSpans.Spans().At(0)
+// validateTrace checks every candidate span against a matcher and applies its quantifier
+func (m *Matcher) validateTrace(matcher contract.TraceMatcher, candidates []spanCandidate) error {
+	results := make([]candidateResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		results = append(results, candidateResult{
+			location:   candidate.location,
+			mismatches: m.matchSpan(matcher, candidate),
+			context:    candidate.diffContext,
+		})
+	}
+	return evaluateResults(matcher.Quantifier, matcher.Count, "span", results)
+}
+
+// matchSpan returns every expectation of the matcher that the candidate span fails
+func (m *Matcher) matchSpan(matcher contract.TraceMatcher, candidate spanCandidate) []mismatch {
+	var mismatches []mismatch
+	span := candidate.span
 
 	// Validate span name
 	if matcher.SpanName != "" && span.Name() != matcher.SpanName {
-		return fmt.Errorf("span name mismatch: expected %s, got %s", matcher.SpanName, span.Name())
+		mismatches = append(mismatches, mismatch{
+			field:    "span_name",
+			expected: matcher.SpanName,
+			actual:   span.Name(),
+			message:  fmt.Sprintf("span name mismatch: expected %s, got %s", matcher.SpanName, span.Name()),
+		})
 	}
 
 	// Validate service name
 	if matcher.ServiceName != "" {
-		if serviceName, ok := resourceSpans.Resource().Attributes().Get("service.name"); ok {
+		if serviceName, ok := candidate.resource.Attributes().Get("service.name"); ok {
 			if serviceName.Str() != matcher.ServiceName {
-				return fmt.Errorf("service name mismatch: expected %s, got %s", matcher.ServiceName, serviceName.Str())
+				mismatches = append(mismatches, mismatch{
+					field:    "service_name",
+					expected: matcher.ServiceName,
+					actual:   serviceName.Str(),
+					message:  fmt.Sprintf("service name mismatch: expected %s, got %s", matcher.ServiceName, serviceName.Str()),
+				})
 			}
 		} else {
-			return fmt.Errorf("service name not found in resource attributes")
+			mismatches = append(mismatches, mismatch{
+				field:    "service_name",
+				expected: matcher.ServiceName,
+				message:  "service name not found in resource attributes",
+			})
 		}
 	}
 
 	// Validate attributes
-	for key, expectedValue := range matcher.Attributes {
-		if strings.HasPrefix(key, "!") {
-			// Negation - field should not exist
-			fieldName := strings.TrimPrefix(key, "!")
-			if span.Attributes().Len() > 0 {
-				if _, exists := span.Attributes().Get(fieldName); exists {
-					return fmt.Errorf("attribute %s should not exist", fieldName)
-				}
-			}
-		} else {
-			// Field should exist and match
-			if actualValue, ok := span.Attributes().Get(key); ok {
-				// Handle different value types
-				var actualStr string
-				switch actualValue.Type() {
-				case pcommon.ValueTypeStr:
-					actualStr = actualValue.Str()
-				case pcommon.ValueTypeInt:
-					actualStr = fmt.Sprintf("%d", actualValue.Int())
-				case pcommon.ValueTypeDouble:
-					actualStr = fmt.Sprintf("%f", actualValue.Double())
-				case pcommon.ValueTypeBool:
-					actualStr = fmt.Sprintf("%t", actualValue.Bool())
-				default:
-					actualStr = actualValue.AsString()
-				}
+	mismatches = append(mismatches, m.checkAttributes("attributes", "attribute", matcher.Attributes, span.Attributes())...)
 
-				expectedStr := fmt.Sprintf("%v", expectedValue)
-				// Handle float precision issues by comparing as numbers when possible
-				if actualValue.Type() == pcommon.ValueTypeDouble && expectedValue != nil {
-					if expectedFloat, ok := expectedValue.(float64); ok {
-						if actualValue.Double() == expectedFloat {
-							continue // Values match as floats
-						}
-					} else if expectedInt, ok := expectedValue.(int); ok {
-						if actualValue.Double() == float64(expectedInt) {
-							continue // Values match as int to float
-						}
-					}
-				}
-				if actualStr != expectedStr {
-					return fmt.Errorf("attribute %s mismatch: expected %v, got %s", key, expectedValue, actualStr)
-				}
-			} else {
-				return fmt.Errorf("attribute %s not found", key)
-			}
-		}
+	// Validate resource and scope
+	if matcher.Resource != nil {
+		mismatches = append(mismatches, m.checkResource(matcher.Resource, candidate.resource, candidate.resourceSchemaURL)...)
+	}
+	if matcher.Scope != nil {
+		mismatches = append(mismatches, m.checkScope(matcher.Scope, candidate.scope, candidate.scopeSchemaURL)...)
+	}
+
+	// Validate events and links
+	mismatches = append(mismatches, m.checkEvents(matcher.Events, span)...)
+	mismatches = append(mismatches, m.checkLinks(matcher.Links, span)...)
+
+	// Validate duration and status code
+	if matcher.Duration != nil {
+		mismatches = append(mismatches, checkDuration(matcher.Duration, span)...)
+	}
+	if matcher.StatusCode != nil {
+		mismatches = append(mismatches, checkStatusCode(matcher.StatusCode, span.Attributes())...)
 	}
 
-	return nil
+	return mismatches
 }
 
 // validateMetrics validates metric data against matchers
 func (m *Matcher) validateMetrics(matchers []contract.MetricMatcher, metrics pmetric.Metrics) error {
-	if metrics.ResourceMetrics().Len() == 0 {
-		return fmt.Errorf("no metrics found in output")
-	}
+	candidates := collectMetrics(metrics)
 
+	var failures []error
 	for i, matcher := range matchers {
-		if err := m.validateMetric(matcher, metrics); err != nil {
-			return fmt.Errorf("metric matcher %d failed: %w", i, err)
+		if err := m.validateMetric(matcher, candidates); err != nil {
+			failures = append(failures, newMatcherError(contract.SignalTypeMetrics, "metric", i, err))
 		}
 	}
 
-	return nil
+	return errors.Join(failures...)
+}
+
+// validateMetric checks every candidate metric against a matcher and applies its quantifier
+func (m *Matcher) validateMetric(matcher contract.MetricMatcher, candidates []metricCandidate) error {
+	results := make([]candidateResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		mismatches, labels := m.matchMetric(matcher, candidate)
+		results = append(results, candidateResult{
+			location:   candidate.location,
+			mismatches: mismatches,
+			context:    func() diffContext { return candidate.diffContext(labels) },
+		})
+	}
+	return evaluateResults(matcher.Quantifier, matcher.Count, "metric", results)
 }
 
-// validateMetric validates a single metric against a matcher
-func (m *Matcher) validateMetric(matcher contract.MetricMatcher, metrics pmetric.Metrics) error {
-	resourceMetrics := metrics.ResourceMetrics().At(0)
-	scopeMetrics := resourceMetrics.ScopeMetrics().At(0)
-	metric := scopeMetrics.Metrics().At(0)
+// matchMetric returns every expectation of the matcher that the candidate metric fails,
+// and the labels of the data point the labels were checked against
+func (m *Matcher) matchMetric(matcher contract.MetricMatcher, candidate metricCandidate) ([]mismatch, pcommon.Map) {
+	var mismatches []mismatch
+	metric := candidate.metric
 
 	// Validate metric name
 	if matcher.Name != "" && metric.Name() != matcher.Name {
-		return fmt.Errorf("metric name mismatch: expected %s, got %s", matcher.Name, metric.Name())
+		mismatches = append(mismatches, mismatch{
+			field:    "name",
+			expected: matcher.Name,
+			actual:   metric.Name(),
+			message:  fmt.Sprintf("metric name mismatch: expected %s, got %s", matcher.Name, metric.Name()),
+		})
 	}
 
 	// Validate metric type
 	if matcher.Type != "" {
-		actualType := ""
-		switch metric.Type() {
-		case pmetric.MetricTypeGauge:
-			actualType = "gauge"
-		case pmetric.MetricTypeSum:
-			// Check if it's a counter (monotonic sum) or regular sum
-			if metric.Sum().IsMonotonic() {
-				actualType = "counter"
-			} else {
-				actualType = "sum"
-			}
-		case pmetric.MetricTypeHistogram:
-			actualType = "histogram"
-		}
+		actualType := contract.MetricTypeName(metric)
 		if actualType != matcher.Type {
-			return fmt.Errorf("metric type mismatch: expected %s, got %s", matcher.Type, actualType)
+			mismatches = append(mismatches, mismatch{
+				field:    "type",
+				expected: matcher.Type,
+				actual:   actualType,
+				message:  fmt.Sprintf("metric type mismatch: expected %s, got %s", matcher.Type, actualType),
+			})
 		}
 	}
 
 	// Validate labels
-	for key, expectedValue := range matcher.Labels {
-		var actualValue pcommon.Value
-		var found bool
-		switch metric.Type() {
-		case pmetric.MetricTypeGauge:
-			if metric.Gauge().DataPoints().Len() > 0 {
-				actualValue, found = metric.Gauge().DataPoints().At(0).Attributes().Get(key)
-			}
-		case pmetric.MetricTypeSum:
-			if metric.Sum().DataPoints().Len() > 0 {
-				actualValue, found = metric.Sum().DataPoints().At(0).Attributes().Get(key)
-			}
-		case pmetric.MetricTypeHistogram:
-			if metric.Histogram().DataPoints().Len() > 0 {
-				actualValue, found = metric.Histogram().DataPoints().At(0).Attributes().Get(key)
-			}
-		}
+	labelMismatches, labels := m.checkLabels(matcher.Labels, metric)
+	mismatches = append(mismatches, labelMismatches...)
 
-		if !found {
-			return fmt.Errorf("label %s not found", key)
-		}
-		if actualValue.Str() != expectedValue {
-			return fmt.Errorf("label %s mismatch: expected %v, got %s", key, expectedValue, actualValue.Str())
-		}
+	// Validate resource and scope
+	if matcher.Resource != nil {
+		mismatches = append(mismatches, m.checkResource(matcher.Resource, candidate.resource, candidate.resourceSchemaURL)...)
+	}
+	if matcher.Scope != nil {
+		mismatches = append(mismatches, m.checkScope(matcher.Scope, candidate.scope, candidate.scopeSchemaURL)...)
 	}
 
-	return nil
+	// Validate data point values and histogram shape
+	if matcher.Value != nil {
+		mismatches = append(mismatches, checkValue(matcher.Value, metric)...)
+	}
+	if matcher.Histogram != nil {
+		mismatches = append(mismatches, checkHistogram(matcher.Histogram, metric)...)
+	}
+
+	return mismatches, labels
 }
 
 // validateLogs validates log data against matchers
 func (m *Matcher) validateLogs(matchers []contract.LogMatcher, logs plog.Logs) error {
-	if logs.ResourceLogs().Len() == 0 {
-		return fmt.Errorf("no logs found in output")
-	}
+	candidates := collectLogs(logs)
 
+	var failures []error
 	for i, matcher := range matchers {
-		if err := m.validateLog(matcher, logs); err != nil {
-			return fmt.Errorf("log matcher %d failed: %w", i, err)
+		if err := m.validateLog(matcher, candidates); err != nil {
+			failures = append(failures, newMatcherError(contract.SignalTypeLogs, "log", i, err))
 		}
 	}
 
-	return nil
+	return errors.Join(failures...)
 }
 
-// validateLog validates a single log against a matcher
-func (m *Matcher) validateLog(matcher contract.LogMatcher, logs plog.Logs) error {
-	resourceLogs := logs.ResourceLogs().At(0)
-	scopeLogs := resourceLogs.ScopeLogs().At(0)
-	logRecord := scopeLogs.LogRecords().At(0)
+// validateLog checks every candidate log record against a matcher and applies its quantifier
+func (m *Matcher) validateLog(matcher contract.LogMatcher, candidates []logCandidate) error {
+	results := make([]candidateResult, 0, len(candidates))
+	for _, candidate := range candidates {
+		results = append(results, candidateResult{
+			location:   candidate.location,
+			mismatches: m.matchLog(matcher, candidate),
+			context:    candidate.diffContext,
+		})
+	}
+	return evaluateResults(matcher.Quantifier, matcher.Count, "log record", results)
+}
+
+// matchLog returns every expectation of the matcher that the candidate log record fails
+func (m *Matcher) matchLog(matcher contract.LogMatcher, candidate logCandidate) []mismatch {
+	var mismatches []mismatch
+	logRecord := candidate.record
 
 	// Validate log body
 	if matcher.Body != "" && logRecord.Body().AsString() != matcher.Body {
-		return fmt.Errorf("log body mismatch: expected %s, got %s", matcher.Body, logRecord.Body().AsString())
+		mismatches = append(mismatches, mismatch{
+			field:    "body",
+			expected: matcher.Body,
+			actual:   logRecord.Body().AsString(),
+			message:  fmt.Sprintf("log body mismatch: expected %s, got %s", matcher.Body, logRecord.Body().AsString()),
+		})
 	}
 
+	// Validate body pattern and structured body fields
+	mismatches = append(mismatches, m.checkBody(matcher, logRecord.Body())...)
+
 	// Validate severity
-	if matcher.Severity != "" && logRecord.SeverityText() != matcher.Severity {
-		return fmt.Errorf("log severity mismatch: expected %s, got %s", matcher.Severity, logRecord.SeverityText())
-	}
+	mismatches = append(mismatches, checkSeverity(matcher.Severity, logRecord)...)
 
 	// Validate attributes
-	for key, expectedValue := range matcher.Attributes {
-		if actualValue, ok := logRecord.Attributes().Get(key); ok {
-			if actualValue.Str() != expectedValue {
-				return fmt.Errorf("log attribute %s mismatch: expected %v, got %s", key, expectedValue, actualValue.Str())
+	mismatches = append(mismatches, m.checkAttributes("attributes", "log attribute", matcher.Attributes, logRecord.Attributes())...)
+
+	// Validate resource and scope
+	if matcher.Resource != nil {
+		mismatches = append(mismatches, m.checkResource(matcher.Resource, candidate.resource, candidate.resourceSchemaURL)...)
+	}
+	if matcher.Scope != nil {
+		mismatches = append(mismatches, m.checkScope(matcher.Scope, candidate.scope, candidate.scopeSchemaURL)...)
+	}
+
+	// Validate timestamp
+	if matcher.Timestamp != nil {
+		mismatches = append(mismatches, m.checkTimestamp(matcher.Timestamp, logRecord)...)
+	}
+
+	return mismatches
+}
+
+// checkAttributes checks an attribute map against expected values. Keys prefixed with !
+// must be absent; every other key must be present and match its expected value.
+func (m *Matcher) checkAttributes(field, name string, expected map[string]interface{}, attributes pcommon.Map) []mismatch {
+	var mismatches []mismatch
+	for _, key := range sortedKeys(expected) {
+		if strings.HasPrefix(key, "!") {
+			// Negation - field should not exist
+			fieldName := strings.TrimPrefix(key, "!")
+			if value, exists := attributes.Get(fieldName); exists {
+				mismatches = append(mismatches, mismatch{
+					field:   field + "." + fieldName,
+					actual:  contract.ValueToInterface(value),
+					message: fmt.Sprintf("%s %s should not exist", name, fieldName),
+				})
 			}
-		} else {
-			return fmt.Errorf("log attribute %s not found", key)
+			continue
+		}
+
+		actual, found := attributes.Get(key)
+		if failure := m.compareAttribute(field+"."+key, name+" "+key, expected[key], actual, found); failure != nil {
+			mismatches = append(mismatches, *failure)
 		}
 	}
+	return mismatches
+}
 
-	return nil
+// compareAttribute checks an attribute against its expected value, which is either a literal
+// compared using the matcher's comparison options or an inline operator expression
+func (m *Matcher) compareAttribute(field, name string, expected interface{}, actual pcommon.Value, found bool) *mismatch {
+	if literal, ok := contract.ParseLiteral(expected); ok {
+		expected = literal
+	} else if expression, ok := contract.ParseExpression(expected); ok {
+		if ok, msg := expression.Evaluate(actual, found, m.comparison); !ok {
+			failure := &mismatch{
+				field:    field,
+				expected: expected,
+				message:  fmt.Sprintf("%s mismatch: %s", name, msg),
+			}
+			if found {
+				failure.actual = contract.ValueToInterface(actual)
+			}
+			return failure
+		}
+		return nil
+	}
+
+	if !found {
+		return &mismatch{
+			field:    field,
+			expected: expected,
+			message:  fmt.Sprintf("%s not found", name),
+		}
+	}
+
+	ok, msg := contract.CompareValue(expected, actual, m.comparison)
+	if ok {
+		return nil
+	}
+	return &mismatch{
+		field:    field,
+		expected: expected,
+		actual:   contract.ValueToInterface(actual),
+		message:  fmt.Sprintf("%s mismatch: %s", name, msg),
+	}
+}
+
+// sortedKeys returns the keys of a matcher map in a stable order
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
@@ -0,0 +1,992 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// newTestOutput builds output data with two resources holding several spans, metrics and logs
+func newTestOutput() contract.OpenTelemetryData {
+	traces := ptrace.NewTraces()
+	for _, service := range []string{"frontend", "backend"} {
+		resourceSpans := traces.ResourceSpans().AppendEmpty()
+		resourceSpans.Resource().Attributes().PutStr("service.name", service)
+		scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+		for _, name := range []string{"GET /users", "SELECT users"} {
+			span := scopeSpans.Spans().AppendEmpty()
+			span.SetName(name)
+			span.Attributes().PutStr("service", service)
+		}
+	}
+
+	metrics := pmetric.NewMetrics()
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	for _, name := range []string{"requests_total", "errors_total"} {
+		metric := scopeMetrics.Metrics().AppendEmpty()
+		metric.SetName(name)
+		metric.SetEmptySum().SetIsMonotonic(true)
+		metric.Sum().DataPoints().AppendEmpty().Attributes().PutStr("method", "GET")
+	}
+
+	logs := plog.NewLogs()
+	scopeLogs := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	for _, body := range []string{"started", "stopped"} {
+		record := scopeLogs.LogRecords().AppendEmpty()
+		record.Body().SetStr(body)
+		record.SetSeverityText("INFO")
+	}
+
+	return contract.OpenTelemetryData{
+		Traces:  traces,
+		Metrics: metrics,
+		Logs:    logs,
+		Time:    time.Now(),
+	}
+}
+
+func TestMatcher_QuantifierAny(t *testing.T) {
+	m := NewMatcher()
+	output := newTestOutput()
+
+	// A span in the second resource must be found
+	err := m.validateTraces([]contract.TraceMatcher{{
+		SpanName:    "SELECT users",
+		ServiceName: "backend",
+	}}, output.Traces)
+	assert.NoError(t, err)
+
+	err = m.validateMetrics([]contract.MetricMatcher{{Name: "errors_total"}}, output.Metrics)
+	assert.NoError(t, err)
+
+	err = m.validateLogs([]contract.LogMatcher{{Body: "stopped"}}, output.Logs)
+	assert.NoError(t, err)
+
+	// The error reports the candidate count and the closest failure
+	err = m.validateTraces([]contract.TraceMatcher{{
+		SpanName:    "SELECT users",
+		ServiceName: "billing",
+	}}, output.Traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "4 candidates checked")
+	assert.Contains(t, err.Error(), "closest candidate at resource 0, scope 0, span 1")
+	assert.Contains(t, err.Error(), "service name mismatch: expected billing, got frontend")
+}
+
+func TestMatcher_QuantifierAll(t *testing.T) {
+	m := NewMatcher()
+	output := newTestOutput()
+
+	err := m.validateMetrics([]contract.MetricMatcher{{
+		Labels:     map[string]interface{}{"method": "GET"},
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierAll},
+	}}, output.Metrics)
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		SpanName:   "GET /users",
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierAll},
+	}}, output.Traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 4 span candidates did not match")
+
+	// all requires at least one candidate
+	err = m.validateLogs([]contract.LogMatcher{{
+		Body:       "started",
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierAll},
+	}}, plog.NewLogs())
+	assert.Error(t, err)
+}
+
+func TestMatcher_QuantifierNone(t *testing.T) {
+	m := NewMatcher()
+	output := newTestOutput()
+
+	err := m.validateLogs([]contract.LogMatcher{{
+		Severity:   contract.SeverityMatcher{Text: "ERROR"},
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierNone},
+	}}, output.Logs)
+	assert.NoError(t, err)
+
+	// none passes on empty output
+	err = m.validateTraces([]contract.TraceMatcher{{
+		SpanName:   "GET /users",
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierNone},
+	}}, ptrace.NewTraces())
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		SpanName:   "GET /users",
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierNone},
+	}}, output.Traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 4 candidates matched")
+}
+
+func TestMatcher_QuantifierExactly(t *testing.T) {
+	m := NewMatcher()
+	output := newTestOutput()
+
+	err := m.validateTraces([]contract.TraceMatcher{{
+		SpanName:   "GET /users",
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierExactly, Count: 2},
+	}}, output.Traces)
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		SpanName:   "GET /users",
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierExactly, Count: 3},
+	}}, output.Traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected exactly 3 matching span, got 2")
+}
+
+func TestMatcher_CountMatcher(t *testing.T) {
+	m := NewMatcher()
+	output := newTestOutput()
+
+	two := 2
+	err := m.validateTraces([]contract.TraceMatcher{{
+		SpanName: "GET /users",
+		Count:    &contract.CountMatcher{Expected: 2},
+	}}, output.Traces)
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		SpanName: "GET /users",
+		Count:    &contract.CountMatcher{Max: &two, Min: &two},
+	}}, output.Traces)
+	assert.NoError(t, err)
+
+	err = m.validateLogs([]contract.LogMatcher{{
+		Severity: contract.SeverityMatcher{Text: "INFO"},
+		Count:    &contract.CountMatcher{Operator: contract.FilterOperatorGreaterThan, Value: 2},
+	}}, output.Logs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "greater_than 2, got 2")
+
+	// A count of zero passes without the default any quantifier
+	err = m.validateMetrics([]contract.MetricMatcher{{
+		Name:  "missing_total",
+		Count: &contract.CountMatcher{Expected: 0},
+	}}, output.Metrics)
+	assert.NoError(t, err)
+}
+
+func TestMatcher_DurationAndStatusCode(t *testing.T) {
+	m := NewMatcher()
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	start := time.Now()
+	span.SetName("GET /users")
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(150 * time.Millisecond)))
+	span.Attributes().PutInt("http.response.status_code", 503)
+
+	err := m.validateTraces([]contract.TraceMatcher{{
+		Duration:   &contract.DurationMatcher{Min: "100ms", Max: "1s"},
+		StatusCode: &contract.StatusCodeMatcher{Class: "5xx", NotAllowed: []int{500}},
+	}}, traces)
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		Duration: &contract.DurationMatcher{Expected: "100ms", Tolerance: "10ms"},
+	}}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "span duration mismatch")
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		StatusCode: &contract.StatusCodeMatcher{Class: "2xx"},
+	}}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status code 503 is not in class 2xx")
+}
+
+func TestMatcher_ValueAndHistogram(t *testing.T) {
+	m := NewMatcher()
+	metrics := pmetric.NewMetrics()
+	scopeMetrics := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+
+	gauge := scopeMetrics.Metrics().AppendEmpty()
+	gauge.SetName("cpu_usage")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(0.52)
+
+	histogram := scopeMetrics.Metrics().AppendEmpty()
+	histogram.SetName("latency")
+	point := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	point.ExplicitBounds().FromRaw([]float64{10, 100})
+	point.BucketCounts().FromRaw([]uint64{1, 3, 0})
+	point.SetCount(4)
+	point.SetSum(180)
+
+	err := m.validateMetrics([]contract.MetricMatcher{{
+		Name:  "cpu_usage",
+		Value: &contract.ValueMatcher{Expected: 0.5, Tolerance: 5},
+	}}, metrics)
+	assert.NoError(t, err)
+
+	err = m.validateMetrics([]contract.MetricMatcher{{
+		Name:  "cpu_usage",
+		Value: &contract.ValueMatcher{Expected: 0.5, Tolerance: 1},
+	}}, metrics)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not within 1% of 0.5")
+
+	err = m.validateMetrics([]contract.MetricMatcher{{
+		Name:  "cpu_usage",
+		Value: &contract.ValueMatcher{Range: &contract.ValueRange{Min: 0, Max: 1}},
+	}}, metrics)
+	assert.NoError(t, err)
+
+	count, sum := 4, 180.0
+	err = m.validateMetrics([]contract.MetricMatcher{{
+		Name: "latency",
+		Histogram: &contract.HistogramMatcher{
+			Buckets:      []float64{10, 100},
+			Count:        &count,
+			Sum:          &sum,
+			BucketCounts: map[float64]int{100: 3},
+		},
+	}}, metrics)
+	assert.NoError(t, err)
+
+	// A zero count or sum is asserted, not treated as unset
+	zeroCount, zeroSum := 0, 0.0
+	err = m.validateMetrics([]contract.MetricMatcher{{
+		Name:      "latency",
+		Histogram: &contract.HistogramMatcher{Count: &zeroCount},
+	}}, metrics)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "histogram count mismatch: expected 0, got 4")
+
+	err = m.validateMetrics([]contract.MetricMatcher{{
+		Name:      "latency",
+		Histogram: &contract.HistogramMatcher{Sum: &zeroSum},
+	}}, metrics)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "histogram sum mismatch: expected 0, got 180")
+
+	err = m.validateMetrics([]contract.MetricMatcher{{
+		Name:      "latency",
+		Histogram: &contract.HistogramMatcher{BucketCounts: map[float64]int{10: 2}},
+	}}, metrics)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bucket 10 count mismatch: expected 2, got 1")
+}
+
+func TestMatcher_Timestamp(t *testing.T) {
+	m := NewMatcher()
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.SetTimestamp(pcommon.NewTimestampFromTime(time.Now().Truncate(time.Second)))
+
+	err := m.validateLogs([]contract.LogMatcher{{
+		Timestamp: &contract.TimestampMatcher{Relative: "within_last_hour", Precision: "second"},
+	}}, logs)
+	assert.NoError(t, err)
+
+	err = m.validateLogs([]contract.LogMatcher{{
+		Timestamp: &contract.TimestampMatcher{Range: &contract.ValueRange{Max: "2000-01-01T00:00:00Z"}},
+	}}, logs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is after range end")
+}
+
+func TestMatcher_ValidateSkipsFilteredContracts(t *testing.T) {
+	m := NewMatcher()
+	output := newTestOutput()
+	contractDef := &contract.Contract{
+		Matchers: contract.Matchers{
+			Traces: []contract.TraceMatcher{{SpanName: "missing"}},
+		},
+		Filters: []contract.Filter{
+			{Field: "span.service", Operator: contract.FilterOperatorOneOf, Value: []interface{}{"billing"}},
+		},
+	}
+
+	result := m.Validate(contractDef, output, output)
+	assert.True(t, result.Skipped)
+	assert.True(t, result.Valid)
+	assert.Contains(t, result.SkipReason, "filter 0 did not match")
+
+	contractDef.Filters[0].Operator = contract.FilterOperatorNotOneOf
+	result = m.Validate(contractDef, output, output)
+	assert.False(t, result.Skipped)
+	assert.False(t, result.Valid)
+}
+
+func TestMatcher_TypedAttributes(t *testing.T) {
+	m := NewMatcher()
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.Attributes().PutInt("retry.count", 3)
+	record.Attributes().PutBool("cache.hit", true)
+	record.Attributes().PutEmptySlice("tags").FromRaw([]interface{}{"a", "b"})
+
+	err := m.validateLogs([]contract.LogMatcher{{
+		Attributes: map[string]interface{}{
+			"retry.count": 3,
+			"cache.hit":   true,
+			"tags":        []interface{}{"a", "b"},
+		},
+	}}, logs)
+	assert.NoError(t, err)
+
+	// Strings equal numbers unless strict types are enabled
+	err = m.validateLogs([]contract.LogMatcher{{
+		Attributes: map[string]interface{}{"retry.count": "3"},
+	}}, logs)
+	assert.NoError(t, err)
+
+	m.SetCompareOptions(contract.CompareOptions{StrictTypes: true})
+	err = m.validateLogs([]contract.LogMatcher{{
+		Attributes: map[string]interface{}{"retry.count": "3"},
+	}}, logs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `log attribute retry.count mismatch: expected str "3", got int 3`)
+}
+
+func TestMatcher_ContractComparisonOverride(t *testing.T) {
+	m := NewMatcher()
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("queue_depth")
+	metric.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().PutDouble("ratio", 0.333)
+	output := contract.OpenTelemetryData{Metrics: metrics}
+
+	contractDef := &contract.Contract{
+		Matchers: contract.Matchers{
+			Metrics: []contract.MetricMatcher{{
+				Name:   "queue_depth",
+				Labels: map[string]interface{}{"ratio": 0.33},
+			}},
+		},
+	}
+
+	result := m.Validate(contractDef, output, output)
+	require.False(t, result.Valid)
+	assert.Contains(t, result.Errors[0].Message, "label ratio mismatch: expected double 0.33, got double 0.333")
+
+	contractDef.Matchers.Comparison = &contract.CompareOptions{Tolerance: 0.01}
+	result = m.Validate(contractDef, output, output)
+	assert.True(t, result.Valid)
+}
+
+func TestMatcher_AttributeExpressions(t *testing.T) {
+	m := NewMatcher()
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /api/v1/users")
+	span.Attributes().PutStr("http.route", "/api/v1/users")
+	span.Attributes().PutInt("http.response.status_code", 200)
+
+	err := m.validateTraces([]contract.TraceMatcher{{
+		Attributes: map[string]interface{}{
+			"http.route":                map[string]interface{}{"matches": "^/api/v\\d+"},
+			"http.response.status_code": map[string]interface{}{"gte": 200, "lt": 300, "type": "int"},
+			"user.id":                   map[string]interface{}{"exists": false},
+		},
+	}}, traces)
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		Attributes: map[string]interface{}{
+			"http.route": map[string]interface{}{"one_of": []interface{}{"/health", "/ready"}},
+		},
+	}}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `attribute http.route mismatch: expected one_of [/health /ready], got str "/api/v1/users"`)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		Attributes: map[string]interface{}{"user.id": map[string]interface{}{"type": "str"}},
+	}}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "attribute user.id mismatch: expected to exist")
+
+	// A map attribute whose keys look like an expression is matched through {literal: ...}
+	span.Attributes().PutEmptyMap("payload").PutStr("type", "x")
+	err = m.validateTraces([]contract.TraceMatcher{{
+		Attributes: map[string]interface{}{
+			"payload": map[string]interface{}{"literal": map[string]interface{}{"type": "x"}},
+		},
+	}}, traces)
+	assert.NoError(t, err)
+}
+
+func TestMatcher_ResourceAndScope(t *testing.T) {
+	m := NewMatcher()
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	resourceSpans.Resource().Attributes().PutStr("service.name", "checkout")
+	resourceSpans.Resource().Attributes().PutStr("deployment.environment", "production")
+	resourceSpans.Resource().Attributes().PutInt("host.cpu.count", 8)
+	scopeSpans := resourceSpans.ScopeSpans().AppendEmpty()
+	scopeSpans.Scope().SetName("io.opentelemetry.http")
+	scopeSpans.Scope().SetVersion("1.2.0")
+	scopeSpans.Scope().Attributes().PutStr("library.language", "go")
+	scopeSpans.Spans().AppendEmpty().SetName("GET /cart")
+
+	err := m.validateTraces([]contract.TraceMatcher{{
+		SpanName: "GET /cart",
+		Resource: &contract.ResourceMatcher{
+			Attributes: map[string]interface{}{
+				"deployment.environment": "production",
+				"host.cpu.count":         map[string]interface{}{"gt": 0},
+				"!k8s.pod.uid":           nil,
+			},
+			SchemaURL: "https://opentelemetry.io/schemas/1.26.0",
+		},
+		Scope: &contract.ScopeMatcher{
+			Name:       "io.opentelemetry.http",
+			Version:    "1.2.0",
+			Attributes: map[string]interface{}{"library.language": "go"},
+		},
+	}}, traces)
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		Resource: &contract.ResourceMatcher{
+			Attributes: map[string]interface{}{"deployment.environment": "staging"},
+		},
+		Scope: &contract.ScopeMatcher{Version: "2.0.0"},
+	}}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `resource attribute deployment.environment mismatch: expected str "staging", got str "production"`)
+	assert.Contains(t, err.Error(), `scope version mismatch: expected 2.0.0, got "1.2.0"`)
+}
+
+func TestMatcher_SpanEventsAndLinks(t *testing.T) {
+	m := NewMatcher()
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("process")
+	event := span.Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "TimeoutError")
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	link.SetSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	err := m.validateTraces([]contract.TraceMatcher{{
+		Events: []contract.SpanEventMatcher{{Name: "exception"}},
+		Links: []contract.SpanLinkMatcher{{
+			TraceID: "0102030405060708090a0b0c0d0e0f10",
+			SpanID:  "0102030405060708",
+		}},
+	}}, traces)
+	assert.NoError(t, err)
+
+	err = m.validateTraces([]contract.TraceMatcher{{
+		Events: []contract.SpanEventMatcher{{
+			Name:       "exception",
+			Attributes: map[string]interface{}{"exception.type": "ValueError"},
+		}},
+	}}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "events[0]: no span event matched (1 checked); closest at event 0 failed")
+
+	// Named spans resolve only against contract inputs
+	err = m.validateTraces([]contract.TraceMatcher{{
+		Links: []contract.SpanLinkMatcher{{Span: "enqueue"}},
+	}}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "linked span enqueue is not a contract input span")
+}
+
+// newTraceTree builds a trace of spans from name/parent pairs, where an empty parent marks the root
+func newTraceTree(traces ptrace.Traces, traceID byte, spans [][2]string) {
+	scopeSpans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	ids := make(map[string]pcommon.SpanID)
+	for i, pair := range spans {
+		ids[pair[0]] = pcommon.SpanID([8]byte{traceID, byte(i + 1)})
+	}
+	for _, pair := range spans {
+		span := scopeSpans.Spans().AppendEmpty()
+		span.SetName(pair[0])
+		span.SetTraceID(pcommon.TraceID([16]byte{traceID}))
+		span.SetSpanID(ids[pair[0]])
+		if pair[1] != "" {
+			parentID, ok := ids[pair[1]]
+			if !ok {
+				parentID = pcommon.SpanID([8]byte{0xff})
+			}
+			span.SetParentSpanID(parentID)
+		}
+	}
+}
+
+func TestMatcher_TraceStructure(t *testing.T) {
+	m := NewMatcher()
+	traces := ptrace.NewTraces()
+	newTraceTree(traces, 1, [][2]string{{"GET /cart", ""}, {"load cart", "GET /cart"}, {"SELECT carts", "load cart"}})
+	newTraceTree(traces, 2, [][2]string{{"GET /cart", ""}, {"load cart", "GET /cart"}})
+
+	two := 2
+	err := m.validateTraceStructure(&contract.TraceStructureMatcher{
+		TraceCount:    &contract.CountMatcher{Expected: 2},
+		SpansPerTrace: &contract.CountMatcher{Min: &two},
+		RootSpan:      "GET /cart",
+		Edges: []contract.SpanEdge{
+			{Parent: "GET /cart", Child: "load cart"},
+			{Parent: "load cart", Child: "SELECT carts"},
+		},
+		MaxDepth: 3,
+	}, traces)
+	assert.NoError(t, err)
+
+	err = m.validateTraceStructure(&contract.TraceStructureMatcher{
+		TraceCount: &contract.CountMatcher{Expected: 1},
+		Edges:      []contract.SpanEdge{{Parent: "GET /cart", Child: "SELECT carts"}},
+		MaxDepth:   2,
+	}, traces)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "trace count: expected 1, got 2")
+	assert.Contains(t, err.Error(), "edge GET /cart -> SELECT carts: span SELECT carts has parent load cart")
+	assert.Contains(t, err.Error(), "has depth 3, exceeding max depth 2")
+
+	// A dropped parent leaves its child orphaned
+	orphaned := ptrace.NewTraces()
+	newTraceTree(orphaned, 3, [][2]string{{"GET /cart", ""}, {"SELECT carts", "load cart"}})
+	err = m.validateTraceStructure(&contract.TraceStructureMatcher{RootSpan: "GET /cart"}, orphaned)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "span SELECT carts in trace")
+	assert.Contains(t, err.Error(), "is orphaned")
+
+	err = m.validateTraceStructure(&contract.TraceStructureMatcher{AllowOrphans: true}, orphaned)
+	assert.NoError(t, err)
+}
+
+func TestMatcher_Expectations(t *testing.T) {
+	m := NewMatcher()
+	input := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	spans := input.Traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i, name := range []string{"GET /health", "GET /cart", "GET /users"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.SetTraceID(pcommon.TraceID([16]byte{byte(i + 1)}))
+		span.SetSpanID(pcommon.SpanID([8]byte{byte(i + 1)}))
+		span.Attributes().PutStr("http.route", name)
+	}
+
+	// The pipeline drops the health check and redacts the route of the users span
+	output := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	outSpans := output.Traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.At(1).CopyTo(outSpans.AppendEmpty())
+	spans.At(2).CopyTo(outSpans.AppendEmpty())
+	outSpans.At(1).Attributes().PutStr("http.route", "redacted")
+
+	contractDef := &contract.Contract{
+		Inputs: contract.Inputs{Traces: []contract.TraceInput{
+			{SpanName: "GET /health", Expect: contract.ExpectDropped},
+			{SpanName: "GET /cart"},
+			{SpanName: "GET /users"},
+		}},
+		Matchers: contract.Matchers{Expect: &contract.Expectations{Traces: contract.ExpectUnchanged}},
+	}
+	result := m.Validate(contractDef, input, output)
+	require.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "expectation", result.Errors[0].Type)
+	assert.Equal(t, 2, result.Errors[0].Index)
+	assert.Equal(t, "modified", result.Errors[0].Actual)
+	assert.Contains(t, result.Errors[0].Message,
+		`trace input 2 (span GET /users): expected unchanged, but it was modified: attributes.http.route: str "GET /users" -> str "redacted"`)
+
+	contractDef.Inputs.Traces[2].Expect = contract.ExpectPassedThrough
+	result = m.Validate(contractDef, input, output)
+	assert.True(t, result.Valid, "errors: %v", result.Errors)
+
+	// Every input expected to be dropped, but the pipeline passes two of them through
+	contractDef.Matchers.Expect.Traces = contract.ExpectDropped
+	contractDef.Inputs.Traces[2].Expect = ""
+	result = m.Validate(contractDef, input, output)
+	require.Len(t, result.Errors, 2)
+	assert.Contains(t, result.Errors[0].Message, "trace input 1 (span GET /cart): expected dropped, but it was unchanged")
+	assert.Contains(t, result.Errors[1].Message, "trace input 2 (span GET /users): expected dropped, but it was modified")
+}
+
+func TestMatcher_ExpectDroppedWithEmptyOutput(t *testing.T) {
+	m := NewMatcher()
+	input := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	record := input.Logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.Body().SetStr("debug: cache warmed")
+	output := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+
+	contractDef := &contract.Contract{
+		Publisher: "test-service",
+		Pipeline:  "test-pipeline",
+		Version:   "1.0",
+		Inputs:    contract.Inputs{Logs: []contract.LogInput{{Body: "debug: cache warmed", Expect: contract.ExpectDropped}}},
+		Matchers:  contract.Matchers{Logs: []contract.LogMatcher{{Quantifier: contract.Quantifier{Mode: contract.QuantifierNone}}}},
+	}
+	assert.True(t, contractDef.Validate(input, output).Valid)
+	result := m.Validate(contractDef, input, output)
+	assert.True(t, result.Valid, "errors: %v", result.Errors)
+
+	contractDef.Inputs.Logs[0].Expect = contract.ExpectPassedThrough
+	result = m.Validate(contractDef, input, output)
+	require.False(t, result.Valid)
+	assert.Contains(t, result.Errors[len(result.Errors)-1].Message, `log input 0 (log "debug: cache warmed"): expected passed_through, but it was dropped`)
+}
+
+func TestMatcher_FailureDiff(t *testing.T) {
+	m := NewMatcher()
+	output := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	resourceSpans := output.Traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr("service.name", "cart")
+	span := resourceSpans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.Attributes().PutStr("http.method", "GET")
+	span.Attributes().PutStr("http.route", "/cart")
+	span.Attributes().PutStr("debug", "1")
+
+	contractDef := &contract.Contract{Matchers: contract.Matchers{Traces: []contract.TraceMatcher{{
+		SpanName: "GET /cart",
+		Attributes: map[string]interface{}{
+			"http.method":         "POST",
+			"http.request.method": "GET",
+			"!debug":              true,
+		},
+	}}}}
+	result := m.Validate(contractDef, contract.OpenTelemetryData{}, output)
+	require.Len(t, result.Errors, 3)
+	diff := result.Errors[0].Diff
+	require.NotNil(t, diff)
+	assert.Nil(t, result.Errors[1].Diff, "the diff is attached once per matcher")
+	assert.Equal(t, contract.SignalTypeTraces, diff.SignalType)
+	assert.Equal(t, "trace matcher 0", diff.Matcher)
+	assert.Equal(t, "resource 0, scope 0, span 0", diff.Location)
+
+	kinds := make(map[string]contract.DiffKind)
+	for _, entry := range diff.Entries {
+		kinds[entry.Field] = entry.Kind
+	}
+	assert.Equal(t, map[string]contract.DiffKind{
+		"attributes.debug":                 contract.DiffAdded,
+		"attributes.http.method":           contract.DiffChanged,
+		"attributes.http.request.method":   contract.DiffRemoved,
+		"attributes.http.route":            contract.DiffUnchanged,
+		"resource.attributes.service.name": contract.DiffUnchanged,
+		"span_name":                        contract.DiffUnchanged,
+	}, kinds)
+}
+
+func TestMatcher_MetricLabelsPerDataPoint(t *testing.T) {
+	m := NewMatcher()
+	output := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	metric := output.Metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("http.server.requests")
+	sum := metric.SetEmptySum()
+	for _, labels := range [][2]string{{"GET", "200"}, {"POST", "500"}, {"POST", "201"}} {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetIntValue(1)
+		dp.Attributes().PutStr("method", labels[0])
+		dp.Attributes().PutStr("status", labels[1])
+	}
+
+	// The matching series is the last data point
+	contractDef := &contract.Contract{Matchers: contract.Matchers{Metrics: []contract.MetricMatcher{{
+		Name:   "http.server.requests",
+		Labels: map[string]interface{}{"method": "POST", "status": "201"},
+	}}}}
+	assert.True(t, m.Validate(contractDef, contract.OpenTelemetryData{}, output).Valid)
+
+	// A failure reports and diffs the closest series, not the first
+	contractDef.Matchers.Metrics[0].Labels["status"] = "404"
+	result := m.Validate(contractDef, contract.OpenTelemetryData{}, output)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "labels.status", result.Errors[0].Field)
+	assert.Equal(t, "500", result.Errors[0].Actual)
+	diff := result.Errors[0].Diff
+	require.NotNil(t, diff)
+	kinds := make(map[string]contract.DiffKind)
+	for _, entry := range diff.Entries {
+		kinds[entry.Field] = entry.Kind
+	}
+	assert.Equal(t, map[string]contract.DiffKind{
+		"labels.method": contract.DiffUnchanged,
+		"labels.status": contract.DiffChanged,
+		"name":          contract.DiffUnchanged,
+	}, kinds)
+}
+
+func TestAdvancedMatcher_RulesApplyToEveryItem(t *testing.T) {
+	am := NewAdvancedMatcher(nil)
+	data := newTestOutput()
+
+	// Every span carries a service attribute
+	require.NoError(t, am.ValidateRule(contract.ValidationRule{
+		Field:    "spans[*].attributes.service",
+		Operator: contract.FilterOperatorOneOf,
+		Values:   []interface{}{"frontend", "backend"},
+	}, data))
+
+	// Only the first resource is frontend, so the rule fails on a later span
+	err := am.ValidateRule(contract.ValidationRule{
+		Field:    "span.service.name",
+		Operator: contract.FilterOperatorEquals,
+		Value:    "frontend",
+	}, data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "item 2: field span.service.name: expected frontend, got backend")
+
+	// Filters narrow a rule to the matching items
+	require.NoError(t, am.ValidateRule(contract.ValidationRule{
+		Field:    `spans[name="SELECT users"].name`,
+		Operator: contract.FilterOperatorStartsWith,
+		Value:    "SELECT",
+	}, data))
+}
+
+func TestMatcher_ReportsEveryFailure(t *testing.T) {
+	m := NewMatcher()
+	output := newTestOutput()
+
+	contractDef := &contract.Contract{Matchers: contract.Matchers{
+		Traces: []contract.TraceMatcher{
+			{SpanName: "GET /users", Attributes: map[string]interface{}{"service": "frontend"}},
+			{SpanName: "GET /orders", Attributes: map[string]interface{}{"service": "checkout"}},
+		},
+		Logs: []contract.LogMatcher{{Body: "started", Severity: contract.SeverityMatcher{Text: "FATAL"}}},
+		TraceStructure: &contract.TraceStructureMatcher{
+			RootSpan: "GET /users",
+			MaxDepth: 1,
+		},
+	}}
+	result := m.Validate(contractDef, output, output)
+	require.False(t, result.Valid)
+
+	type failure struct {
+		errorType string
+		index     int
+		field     string
+	}
+	var failures []failure
+	for _, err := range result.Errors {
+		failures = append(failures, failure{err.Type, err.Index, err.Field})
+	}
+	assert.Contains(t, failures, failure{"trace_validation", 1, "span_name"})
+	assert.Contains(t, failures, failure{"trace_validation", 1, "attributes.service"})
+	assert.NotContains(t, failures, failure{"trace_validation", 0, "span_name"}, "trace matcher 0 passes")
+	assert.Contains(t, failures, failure{"log_validation", 0, "severity"})
+
+	structureFailures := 0
+	for _, err := range result.Errors {
+		if err.Type == "log_validation" && err.Field == "severity" {
+			assert.Equal(t, "FATAL", err.Expected)
+			assert.Equal(t, contract.SignalTypeLogs, err.SignalType)
+			assert.Contains(t, err.Message, "log matcher 0 failed: ")
+		}
+		if err.Type == "trace_structure" {
+			structureFailures++
+			assert.Equal(t, "root_span", err.Field)
+		}
+	}
+	assert.Positive(t, structureFailures)
+}
+
+func TestMatcher_Semconv(t *testing.T) {
+	m := NewMatcher()
+	output := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	span := output.Traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /users")
+	span.SetKind(ptrace.SpanKindServer)
+	span.Attributes().PutStr("http.request.method", "GET")
+	span.Attributes().PutStr("url.path", "/users")
+
+	contractDef := &contract.Contract{Matchers: contract.Matchers{Semconv: &contract.SemconvMatcher{Version: "1.26.0"}}}
+	result := m.Validate(contractDef, output, output)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "semconv", result.Errors[0].Type)
+	assert.Equal(t, "attributes.url.scheme", result.Errors[0].Field)
+	assert.Equal(t, contract.SignalTypeTraces, result.Errors[0].SignalType)
+	assert.Equal(t, "semconv 1.26.0: span GET /users (http.server): missing required attribute url.scheme", result.Errors[0].Message)
+
+	span.Attributes().PutStr("url.scheme", "https")
+	assert.True(t, m.Validate(contractDef, output, output).Valid)
+}
+
+func TestMatcher_Cardinality(t *testing.T) {
+	m := NewMatcher()
+	output := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	// The same metric under two resources; user.id makes every data point distinct
+	for _, users := range [][]string{{"u-1", "u-2"}, {"u-3", "u-3"}} {
+		metric := output.Metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		metric.SetName("http.server.requests")
+		sum := metric.SetEmptySum()
+		for _, user := range users {
+			dp := sum.DataPoints().AppendEmpty()
+			dp.SetIntValue(1)
+			dp.Attributes().PutStr("method", "GET")
+			dp.Attributes().PutStr("user.id", user)
+		}
+	}
+
+	max := 2
+	contractDef := &contract.Contract{Matchers: contract.Matchers{Cardinality: []contract.CardinalityMatcher{
+		{Metric: "http.server.requests", Max: &max, ForbiddenDimensions: []string{"user.id", "http.route"}},
+	}}}
+	result := m.Validate(contractDef, output, output)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "cardinality", result.Errors[0].Type)
+	assert.Equal(t, "max", result.Errors[0].Field)
+	assert.Equal(t, 3, result.Errors[0].Actual)
+	assert.Equal(t, "cardinality matcher 0 failed: metric http.server.requests has 3 distinct attribute sets, expected at most 2 (user.id: 3 values, method: 1 value)", result.Errors[0].Message)
+	assert.Equal(t, "labels.user.id", result.Errors[1].Field)
+	assert.Equal(t, "cardinality matcher 0 failed: metric http.server.requests has forbidden dimension user.id on 4 of 4 data points", result.Errors[1].Message)
+
+	// Dropping user.id bounds the metric to a single series
+	for i := 0; i < output.Metrics.ResourceMetrics().Len(); i++ {
+		points := output.Metrics.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+		for j := 0; j < points.Len(); j++ {
+			points.At(j).Attributes().Remove("user.id")
+		}
+	}
+	assert.True(t, m.Validate(contractDef, output, output).Valid)
+
+	contractDef.Matchers.Cardinality[0].Metric = "http.server.missing"
+	result = m.Validate(contractDef, output, output)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "cardinality matcher 0 failed: metric http.server.missing not found in output", result.Errors[0].Message)
+}
+
+func TestMatcher_Correlations(t *testing.T) {
+	m := NewMatcher()
+	input := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: plog.NewLogs()}
+	spans := input.Traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i, name := range []string{"GET /cart", "POST /pay"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.SetTraceID(pcommon.TraceID{1})
+		span.SetSpanID(pcommon.SpanID{byte(i + 1)})
+	}
+	records := input.Logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range []string{"cart loaded", "payment failed"} {
+		record := records.AppendEmpty()
+		record.Body().SetStr(body)
+		record.SetTraceID(pcommon.TraceID{1})
+		record.SetSpanID(pcommon.SpanID{2})
+	}
+	metric := input.Metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("http.server.duration")
+	exemplar := metric.SetEmptyGauge().DataPoints().AppendEmpty().Exemplars().AppendEmpty()
+	exemplar.SetTraceID(pcommon.TraceID{1})
+	exemplar.SetSpanID(pcommon.SpanID{9})
+
+	contractDef := &contract.Contract{Matchers: contract.Matchers{Correlations: []contract.CorrelationMatcher{
+		{Source: contract.CorrelationSourceLogs, Body: "payment failed", Span: "POST /pay"},
+		{Source: contract.CorrelationSourceLogs, Body: "cart loaded", Span: "GET /cart"},
+		{Source: contract.CorrelationSourceExemplars, Metric: "http.server.duration"},
+	}}}
+	result := m.Validate(contractDef, input, input)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "correlation", result.Errors[0].Type)
+	assert.Equal(t, contract.SignalTypeLogs, result.Errors[0].SignalType)
+	assert.Equal(t, 1, result.Errors[0].Index)
+	assert.Equal(t, "POST /pay", result.Errors[0].Actual)
+	assert.Equal(t, "correlation 1 failed: log record 0 (resource 0, scope 0, log 0) references span POST /pay, expected input span GET /cart", result.Errors[0].Message)
+	assert.Equal(t, contract.SignalTypeMetrics, result.Errors[1].SignalType)
+	assert.Contains(t, result.Errors[1].Message, "exemplar 0 of metric http.server.duration data point 0")
+	assert.Contains(t, result.Errors[1].Message, "which is not in the output")
+
+	// A dropped span breaks every log that referenced it
+	output := contract.OpenTelemetryData{Traces: ptrace.NewTraces(), Metrics: pmetric.NewMetrics(), Logs: input.Logs}
+	contractDef.Matchers.Correlations = contractDef.Matchers.Correlations[:1]
+	result = m.Validate(contractDef, input, output)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Message, "which is not in the output")
+
+	contractDef.Matchers.Correlations[0].Body = "refund issued"
+	result = m.Validate(contractDef, input, input)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, `correlation 0 failed: no log records with body "refund issued" found in output`, result.Errors[0].Message)
+}
+
+func TestMatcher_LogBodyAndSeverity(t *testing.T) {
+	m := NewMatcher()
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	raw := records.AppendEmpty()
+	raw.Body().SetStr(`{"user": {"id": "u-1"}, "amount": 42, "items": [{"sku": "a"}, {"sku": "b"}]}`)
+	raw.SetSeverityText("WARN")
+	structured := records.AppendEmpty()
+	body := structured.Body().SetEmptyMap()
+	body.PutEmptyMap("user").PutStr("id", "u-2")
+	structured.SetSeverityNumber(plog.SeverityNumberError)
+
+	err := m.validateLogs([]contract.LogMatcher{{
+		ParseJSON:  true,
+		BodyFields: map[string]interface{}{"user.id": "u-1", "amount": map[string]interface{}{"gt": 40}, "items[*].sku": map[string]interface{}{"one_of": []interface{}{"a", "b"}}, "!password": true},
+		Severity:   contract.SeverityMatcher{Min: "WARN", Max: "WARN"},
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierExactly, Count: 1},
+	}}, logs)
+	assert.NoError(t, err)
+
+	err = m.validateLogs([]contract.LogMatcher{{
+		BodyFields: map[string]interface{}{"user.id": "u-2"},
+		Severity:   contract.SeverityMatcher{Min: "ERROR"},
+	}}, logs)
+	assert.NoError(t, err)
+
+	err = m.validateLogs([]contract.LogMatcher{{BodyMatches: `"amount": \d+`, Quantifier: contract.Quantifier{Mode: contract.QuantifierAll}}}, logs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match")
+
+	err = m.validateLogs([]contract.LogMatcher{{BodyMatches: "("}}, logs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid body pattern "("`)
+
+	result := m.Validate(&contract.Contract{Matchers: contract.Matchers{Logs: []contract.LogMatcher{{
+		Severity:   contract.SeverityMatcher{Min: "ERROR"},
+		Quantifier: contract.Quantifier{Mode: contract.QuantifierAll},
+	}}}}, contract.OpenTelemetryData{Logs: logs}, contract.OpenTelemetryData{Logs: logs})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "severity_number", result.Errors[0].Field)
+	assert.Contains(t, result.Errors[0].Message, "log severity Warn (13) is below minimum ERROR")
+
+	err = m.validateLogs([]contract.LogMatcher{{ParseJSON: true, BodyFields: map[string]interface{}{"user.id": "u-1"}, Quantifier: contract.Quantifier{Mode: contract.QuantifierAll}}}, logs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "body field user.id mismatch")
+}
+
+func TestMatcher_Batches(t *testing.T) {
+	m := NewMatcher()
+	output := contract.OpenTelemetryData{
+		Traces:  ptrace.NewTraces(),
+		Metrics: pmetric.NewMetrics(),
+		Logs:    plog.NewLogs(),
+		Batches: []contract.Batch{
+			{Signal: contract.SignalTypeTraces, Items: 2},
+			{Signal: contract.SignalTypeTraces, Items: 1},
+			{Signal: contract.SignalTypeLogs, Items: 4},
+		},
+	}
+
+	contractDef := &contract.Contract{Matchers: contract.Matchers{Batches: &contract.BatchMatcher{
+		Count: &contract.CountMatcher{Expected: 3},
+	}}}
+	assert.True(t, m.Validate(contractDef, output, output).Valid)
+
+	contractDef.Matchers.Batches.Signal = contract.SignalTypeTraces
+	result := m.Validate(contractDef, output, output)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "batches", result.Errors[0].Type)
+	assert.Equal(t, 2, result.Errors[0].Actual)
+	assert.Equal(t, "batches matcher failed: traces batch count expected 3, got 2 (batch sizes 2, 1)", result.Errors[0].Message)
+
+	contractDef.Matchers.Batches.Signal = contract.SignalTypeMetrics
+	result = m.Validate(contractDef, output, output)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "batches matcher failed: metrics batch count expected 3, got 0 (no batches delivered)", result.Errors[0].Message)
+}
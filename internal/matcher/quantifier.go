@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+)
+
+// mismatch describes a single expectation that a candidate item failed to meet
+type mismatch struct {
+	field    string
+	expected interface{}
+	actual   interface{}
+	message  string
+}
+
+// candidateResult records the outcome of checking one candidate item against a matcher
+type candidateResult struct {
+	location   string
+	mismatches []mismatch
+	context    func() diffContext // Describes the candidate for diffs; nil for nested items
+}
+
+// matched reports whether the candidate satisfied every expectation
+func (r candidateResult) matched() bool {
+	return len(r.mismatches) == 0
+}
+
+// Error returns the mismatch message, so failures that are not tied to a candidate
+// can be reported with their field, expected and actual values
+func (mm mismatch) Error() string {
+	return mm.message
+}
+
+// candidateError is a quantifier failure explained by the mismatches of one candidate
+type candidateError struct {
+	headline  string
+	candidate *candidateResult
+	detailed  bool // Whether the message lists the candidate's mismatches
+}
+
+func (e *candidateError) Error() string {
+	if e.detailed {
+		return e.headline + ": " + e.candidate.describe()
+	}
+	return e.headline
+}
+
+// candidateFailure builds a failure explained by the candidate's mismatches, with a
+// diff against it attached
+func candidateFailure(headline string, candidate *candidateResult, detailed bool) error {
+	if candidate == nil {
+		return fmt.Errorf("%s", headline)
+	}
+	return withDiff(&candidateError{headline: headline, candidate: candidate, detailed: detailed}, candidate)
+}
+
+// describe joins the candidate's mismatches into a single message
+func (r candidateResult) describe() string {
+	messages := make([]string, 0, len(r.mismatches))
+	for _, m := range r.mismatches {
+		messages = append(messages, m.message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// evaluateResults applies a matcher's count and quantifier to its candidate results.
+// When a count is given without an explicit quantifier, the count alone decides.
+func evaluateResults(q contract.Quantifier, count *contract.CountMatcher, kind string, results []candidateResult) error {
+	if count != nil {
+		matched := 0
+		for _, result := range results {
+			if result.matched() {
+				matched++
+			}
+		}
+		if err := checkCount(count, kind, matched, len(results)); err != nil {
+			return candidateFailure(err.Error(), closestFailure(results), false)
+		}
+		if q.Mode == "" {
+			return nil
+		}
+	}
+	return applyQuantifier(q, kind, results)
+}
+
+// applyQuantifier decides whether the candidate results satisfy the quantifier.
+// kind names the item type (span, metric, log record) for error messages.
+func applyQuantifier(q contract.Quantifier, kind string, results []candidateResult) error {
+	total := len(results)
+	matched := 0
+	var firstMatch, firstFailure, closest *candidateResult
+	for i := range results {
+		result := &results[i]
+		if result.matched() {
+			matched++
+			if firstMatch == nil {
+				firstMatch = result
+			}
+			continue
+		}
+		if firstFailure == nil {
+			firstFailure = result
+		}
+		if closest == nil || len(result.mismatches) < len(closest.mismatches) {
+			closest = result
+		}
+	}
+
+	switch q.GetMode() {
+	case contract.QuantifierAll:
+		if total == 0 {
+			return fmt.Errorf("no %s candidates found in output", kind)
+		}
+		if matched == total {
+			return nil
+		}
+		return candidateFailure(fmt.Sprintf("%d of %d %s candidates did not match; first failure at %s",
+			total-matched, total, kind, firstFailure.location), firstFailure, true)
+	case contract.QuantifierNone:
+		if matched == 0 {
+			return nil
+		}
+		return fmt.Errorf("expected no matching %s but %d of %d candidates matched (first at %s)",
+			kind, matched, total, firstMatch.location)
+	case contract.QuantifierExactly:
+		if matched == q.Count {
+			return nil
+		}
+		msg := fmt.Sprintf("expected exactly %d matching %s, got %d (%d candidates checked)", q.Count, kind, matched, total)
+		if matched < q.Count && closest != nil {
+			return candidateFailure(msg+fmt.Sprintf("; closest candidate at %s failed", closest.location), closest, true)
+		}
+		return fmt.Errorf("%s", msg)
+	default:
+		if matched > 0 {
+			return nil
+		}
+		if total == 0 {
+			return fmt.Errorf("no %s candidates found in output", kind)
+		}
+		return candidateFailure(fmt.Sprintf("no %s matched (%d candidates checked); closest candidate at %s failed",
+			kind, total, closest.location), closest, true)
+	}
+}
+
+// closestFailure returns the failing candidate with the fewest mismatches
+func closestFailure(results []candidateResult) *candidateResult {
+	var closest *candidateResult
+	for i := range results {
+		if results[i].matched() {
+			continue
+		}
+		if closest == nil || len(results[i].mismatches) < len(closest.mismatches) {
+			closest = &results[i]
+		}
+	}
+	return closest
+}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"github.com/goedelsoup/waveform/internal/contract"
+	"github.com/goedelsoup/waveform/internal/semconv"
+)
+
+// validateSemconv checks output against the semantic conventions selected by the contract
+func (m *Matcher) validateSemconv(matcher *contract.SemconvMatcher, output contract.OpenTelemetryData) []contract.ValidationError {
+	registry, err := semconv.Load(matcher.Version)
+	if err != nil {
+		return []contract.ValidationError{{Type: "semconv", Message: err.Error()}}
+	}
+
+	options := semconv.Options{Recommended: matcher.GetLevel() == contract.SemconvLevelRecommended}
+	var errors []contract.ValidationError
+	for _, violation := range registry.Check(output.Traces, output.Metrics, output.Logs, options) {
+		errors = append(errors, contract.ValidationError{
+			Type:       "semconv",
+			Message:    "semconv " + registry.Version + ": " + violation.Message,
+			Field:      violation.Field,
+			Expected:   violation.Expected,
+			Actual:     violation.Actual,
+			SignalType: contract.SignalType(violation.Signal),
+			Index:      violation.Index,
+		})
+	}
+	return errors
+}
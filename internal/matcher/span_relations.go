@@ -0,0 +1,166 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// spanReference identifies a span by its trace and span IDs
+type spanReference struct {
+	traceID string
+	spanID  string
+}
+
+// collectSpanReferences indexes the IDs of every span by name
+func collectSpanReferences(traces ptrace.Traces) map[string][]spanReference {
+	references := make(map[string][]spanReference)
+	for _, candidate := range collectSpans(traces) {
+		span := candidate.span
+		references[span.Name()] = append(references[span.Name()], spanReference{
+			traceID: span.TraceID().String(),
+			spanID:  span.SpanID().String(),
+		})
+	}
+	return references
+}
+
+// checkEvents checks a span's events against each event matcher
+func (m *Matcher) checkEvents(matchers []contract.SpanEventMatcher, span ptrace.Span) []mismatch {
+	var mismatches []mismatch
+	events := span.Events()
+	for i, matcher := range matchers {
+		field := fmt.Sprintf("events[%d]", i)
+		results := make([]candidateResult, 0, events.Len())
+		for j := 0; j < events.Len(); j++ {
+			event := events.At(j)
+			var eventMismatches []mismatch
+			if matcher.Name != "" && event.Name() != matcher.Name {
+				eventMismatches = append(eventMismatches, mismatch{
+					field:    field + ".name",
+					expected: matcher.Name,
+					actual:   event.Name(),
+					message:  fmt.Sprintf("event name mismatch: expected %s, got %s", matcher.Name, event.Name()),
+				})
+			}
+			eventMismatches = append(eventMismatches,
+				m.checkAttributes(field+".attributes", "event attribute", matcher.Attributes, event.Attributes())...)
+			results = append(results, candidateResult{
+				location:   fmt.Sprintf("event %d", j),
+				mismatches: eventMismatches,
+			})
+		}
+		if failure := relationFailure(field, "span event", matcher.Count, results); failure != nil {
+			mismatches = append(mismatches, *failure)
+		}
+	}
+	return mismatches
+}
+
+// checkLinks checks a span's links against each link matcher
+func (m *Matcher) checkLinks(matchers []contract.SpanLinkMatcher, span ptrace.Span) []mismatch {
+	var mismatches []mismatch
+	links := span.Links()
+	for i, matcher := range matchers {
+		field := fmt.Sprintf("links[%d]", i)
+
+		var targets []spanReference
+		if matcher.Span != "" {
+			targets = m.inputSpans[matcher.Span]
+			if len(targets) == 0 {
+				mismatches = append(mismatches, mismatch{
+					field:    field + ".span",
+					expected: matcher.Span,
+					message:  fmt.Sprintf("linked span %s is not a contract input span", matcher.Span),
+				})
+				continue
+			}
+		}
+
+		results := make([]candidateResult, 0, links.Len())
+		for j := 0; j < links.Len(); j++ {
+			link := links.At(j)
+			actual := spanReference{traceID: link.TraceID().String(), spanID: link.SpanID().String()}
+			var linkMismatches []mismatch
+			if matcher.Span != "" && !containsReference(targets, actual) {
+				linkMismatches = append(linkMismatches, mismatch{
+					field:    field + ".span",
+					expected: matcher.Span,
+					actual:   actual.spanID,
+					message:  fmt.Sprintf("link references trace %s span %s, not input span %s", actual.traceID, actual.spanID, matcher.Span),
+				})
+			}
+			if matcher.TraceID != "" && !strings.EqualFold(actual.traceID, matcher.TraceID) {
+				linkMismatches = append(linkMismatches, mismatch{
+					field:    field + ".trace_id",
+					expected: matcher.TraceID,
+					actual:   actual.traceID,
+					message:  fmt.Sprintf("link trace ID mismatch: expected %s, got %s", matcher.TraceID, actual.traceID),
+				})
+			}
+			if matcher.SpanID != "" && !strings.EqualFold(actual.spanID, matcher.SpanID) {
+				linkMismatches = append(linkMismatches, mismatch{
+					field:    field + ".span_id",
+					expected: matcher.SpanID,
+					actual:   actual.spanID,
+					message:  fmt.Sprintf("link span ID mismatch: expected %s, got %s", matcher.SpanID, actual.spanID),
+				})
+			}
+			linkMismatches = append(linkMismatches,
+				m.checkAttributes(field+".attributes", "link attribute", matcher.Attributes, link.Attributes())...)
+			results = append(results, candidateResult{
+				location:   fmt.Sprintf("link %d", j),
+				mismatches: linkMismatches,
+			})
+		}
+		if failure := relationFailure(field, "span link", matcher.Count, results); failure != nil {
+			mismatches = append(mismatches, *failure)
+		}
+	}
+	return mismatches
+}
+
+// relationFailure applies an event or link matcher's count to its results. Without a
+// count at least one event or link must match.
+func relationFailure(field, kind string, count *contract.CountMatcher, results []candidateResult) *mismatch {
+	matched := 0
+	var closest *candidateResult
+	for i := range results {
+		if results[i].matched() {
+			matched++
+		} else if closest == nil || len(results[i].mismatches) < len(closest.mismatches) {
+			closest = &results[i]
+		}
+	}
+
+	if count != nil {
+		if err := checkCount(count, kind, matched, len(results)); err != nil {
+			return &mismatch{field: field, message: fmt.Sprintf("%s: %v", field, err)}
+		}
+		return nil
+	}
+	if matched > 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s: no %s matched (%d checked)", field, kind, len(results))
+	if closest != nil {
+		message += fmt.Sprintf("; closest at %s failed: %s", closest.location, closest.describe())
+	}
+	return &mismatch{field: field, message: message}
+}
+
+// containsReference reports whether a span reference is among the targets
+func containsReference(targets []spanReference, reference spanReference) bool {
+	for _, target := range targets {
+		if target == reference {
+			return true
+		}
+	}
+	return false
+}
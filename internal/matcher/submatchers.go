@@ -0,0 +1,745 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// statusCodeAttributes lists the attributes a status code is read from, in order of preference
+var statusCodeAttributes = []string{
+	"http.response.status_code",
+	"http.status_code",
+	"rpc.grpc.status_code",
+}
+
+// checkCount validates the number of matched candidates against a count matcher
+func checkCount(count *contract.CountMatcher, kind string, matched, total int) error {
+	if count.Min != nil && matched < *count.Min {
+		return fmt.Errorf("expected at least %d matching %s, got %d (%d candidates checked)", *count.Min, kind, matched, total)
+	}
+	if count.Max != nil && matched > *count.Max {
+		return fmt.Errorf("expected at most %d matching %s, got %d (%d candidates checked)", *count.Max, kind, matched, total)
+	}
+	if count.Operator != "" {
+		if !compareNumeric(float64(matched), float64(count.Value), string(count.Operator)) {
+			return fmt.Errorf("expected matching %s count %s %d, got %d (%d candidates checked)", kind, count.Operator, count.Value, matched, total)
+		}
+		return nil
+	}
+	if count.Min == nil && count.Max == nil && matched != count.Expected {
+		return fmt.Errorf("expected %d matching %s, got %d (%d candidates checked)", count.Expected, kind, matched, total)
+	}
+	return nil
+}
+
+// countFailure describes how a total fails a count matcher, or returns "" when it satisfies it
+func countFailure(count *contract.CountMatcher, actual int) string {
+	if count.Min != nil && actual < *count.Min {
+		return fmt.Sprintf("expected at least %d, got %d", *count.Min, actual)
+	}
+	if count.Max != nil && actual > *count.Max {
+		return fmt.Sprintf("expected at most %d, got %d", *count.Max, actual)
+	}
+	if count.Operator != "" {
+		if !compareNumeric(float64(actual), float64(count.Value), string(count.Operator)) {
+			return fmt.Sprintf("expected %s %d, got %d", count.Operator, count.Value, actual)
+		}
+		return ""
+	}
+	if count.Min == nil && count.Max == nil && actual != count.Expected {
+		return fmt.Sprintf("expected %d, got %d", count.Expected, actual)
+	}
+	return ""
+}
+
+// checkDuration validates a span's duration computed from its start and end timestamps
+func checkDuration(matcher *contract.DurationMatcher, span ptrace.Span) []mismatch {
+	var mismatches []mismatch
+	actual := span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime())
+
+	fail := func(expected interface{}, message string) {
+		mismatches = append(mismatches, mismatch{
+			field:    "duration",
+			expected: expected,
+			actual:   actual.String(),
+			message:  message,
+		})
+	}
+
+	if matcher.Min != "" {
+		minDuration, err := time.ParseDuration(matcher.Min)
+		if err != nil {
+			fail(matcher.Min, fmt.Sprintf("invalid duration min %q: %v", matcher.Min, err))
+		} else if actual < minDuration {
+			fail(matcher.Min, fmt.Sprintf("span duration %s is shorter than minimum %s", actual, minDuration))
+		}
+	}
+
+	if matcher.Max != "" {
+		maxDuration, err := time.ParseDuration(matcher.Max)
+		if err != nil {
+			fail(matcher.Max, fmt.Sprintf("invalid duration max %q: %v", matcher.Max, err))
+		} else if actual > maxDuration {
+			fail(matcher.Max, fmt.Sprintf("span duration %s exceeds maximum %s", actual, maxDuration))
+		}
+	}
+
+	if matcher.Expected != "" {
+		expected, err := time.ParseDuration(matcher.Expected)
+		if err != nil {
+			fail(matcher.Expected, fmt.Sprintf("invalid duration expected %q: %v", matcher.Expected, err))
+			return mismatches
+		}
+
+		var tolerance time.Duration
+		if matcher.Tolerance != "" {
+			tolerance, err = time.ParseDuration(matcher.Tolerance)
+			if err != nil {
+				fail(matcher.Tolerance, fmt.Sprintf("invalid duration tolerance %q: %v", matcher.Tolerance, err))
+				return mismatches
+			}
+		}
+
+		diff := actual - expected
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			fail(matcher.Expected, fmt.Sprintf("span duration mismatch: expected %s (±%s), got %s", expected, tolerance, actual))
+		}
+	}
+
+	return mismatches
+}
+
+// checkStatusCode validates the HTTP or gRPC status code recorded in span attributes
+func checkStatusCode(matcher *contract.StatusCodeMatcher, attributes pcommon.Map) []mismatch {
+	var raw pcommon.Value
+	var key string
+	for _, candidate := range statusCodeAttributes {
+		if value, ok := attributes.Get(candidate); ok {
+			raw, key = value, candidate
+			break
+		}
+	}
+	if key == "" {
+		return []mismatch{{
+			field:   "status_code",
+			message: fmt.Sprintf("status code not found in attributes (looked for %s)", strings.Join(statusCodeAttributes, ", ")),
+		}}
+	}
+
+	numeric, err := contract.ToNumeric(contract.ValueToInterface(raw))
+	if err != nil {
+		return []mismatch{{
+			field:   "status_code",
+			actual:  raw.AsString(),
+			message: fmt.Sprintf("status code attribute %s is not numeric: %s", key, raw.AsString()),
+		}}
+	}
+	code := int(numeric)
+
+	var mismatches []mismatch
+	fail := func(expected interface{}, message string) {
+		mismatches = append(mismatches, mismatch{
+			field:    "status_code",
+			expected: expected,
+			actual:   code,
+			message:  message,
+		})
+	}
+
+	if matcher.Expected != 0 && code != matcher.Expected {
+		fail(matcher.Expected, fmt.Sprintf("status code mismatch: expected %d, got %d", matcher.Expected, code))
+	}
+
+	if matcher.Class != "" {
+		class := strings.ToLower(matcher.Class)
+		if len(class) != 3 || !strings.HasSuffix(class, "xx") || class[0] < '1' || class[0] > '5' {
+			fail(matcher.Class, fmt.Sprintf("invalid status code class %q", matcher.Class))
+		} else if code/100 != int(class[0]-'0') {
+			fail(matcher.Class, fmt.Sprintf("status code %d is not in class %s", code, matcher.Class))
+		}
+	}
+
+	if matcher.Range != nil && !contract.InRange(code, *matcher.Range) {
+		fail(matcher.Range, fmt.Sprintf("status code %d not in range [%v, %v]", code, matcher.Range.Min, matcher.Range.Max))
+	}
+
+	for _, notAllowed := range matcher.NotAllowed {
+		if code == notAllowed {
+			fail(matcher.NotAllowed, fmt.Sprintf("status code %d is not allowed", code))
+		}
+	}
+
+	return mismatches
+}
+
+// checkValue validates that at least one data point of a metric satisfies a value matcher.
+// Histogram data points are compared by their sum.
+func checkValue(matcher *contract.ValueMatcher, metric pmetric.Metric) []mismatch {
+	values := dataPointValues(metric)
+	if len(values) == 0 {
+		return []mismatch{{
+			field:   "value",
+			message: "metric has no data points",
+		}}
+	}
+
+	var firstFailure string
+	for _, value := range values {
+		reason := valueFailure(matcher, value)
+		if reason == "" {
+			return nil
+		}
+		if firstFailure == "" {
+			firstFailure = reason
+		}
+	}
+
+	return []mismatch{{
+		field:    "value",
+		expected: matcher.Expected,
+		actual:   values,
+		message:  fmt.Sprintf("no data point value satisfied the value matcher (values %v): %s", values, firstFailure),
+	}}
+}
+
+// valueFailure returns why a data point value fails a value matcher, or "" if it passes
+func valueFailure(matcher *contract.ValueMatcher, actual float64) string {
+	if matcher.Expected != nil {
+		expected, err := contract.ToNumeric(matcher.Expected)
+		if err != nil {
+			return fmt.Sprintf("expected value %v is not numeric", matcher.Expected)
+		}
+
+		operator := string(matcher.Operator)
+		if operator == "" || operator == string(contract.FilterOperatorEquals) {
+			if !withinTolerance(actual, expected, matcher.Tolerance) {
+				if matcher.Tolerance > 0 {
+					return fmt.Sprintf("value %v is not within %v%% of %v", actual, matcher.Tolerance, expected)
+				}
+				return fmt.Sprintf("value mismatch: expected %v, got %v", expected, actual)
+			}
+		} else if operator == string(contract.FilterOperatorNotEquals) {
+			if withinTolerance(actual, expected, matcher.Tolerance) {
+				return fmt.Sprintf("value %v should not equal %v", actual, expected)
+			}
+		} else if !compareNumeric(actual, expected, operator) {
+			return fmt.Sprintf("value %v is not %s %v", actual, operator, expected)
+		}
+	}
+
+	if matcher.Range != nil && !contract.InRange(actual, *matcher.Range) {
+		return fmt.Sprintf("value %v not in range [%v, %v]", actual, matcher.Range.Min, matcher.Range.Max)
+	}
+
+	return ""
+}
+
+// withinTolerance reports whether actual is within a percentage tolerance of expected
+func withinTolerance(actual, expected, tolerancePercent float64) bool {
+	if tolerancePercent <= 0 {
+		return math.Abs(actual-expected) < 1e-9
+	}
+	return math.Abs(actual-expected) <= math.Abs(expected)*tolerancePercent/100
+}
+
+// dataPointValues returns the numeric value of every data point of a metric
+func dataPointValues(metric pmetric.Metric) []float64 {
+	var values []float64
+	appendNumberPoints := func(points pmetric.NumberDataPointSlice) {
+		for i := 0; i < points.Len(); i++ {
+			point := points.At(i)
+			if point.ValueType() == pmetric.NumberDataPointValueTypeInt {
+				values = append(values, float64(point.IntValue()))
+			} else {
+				values = append(values, point.DoubleValue())
+			}
+		}
+	}
+
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		appendNumberPoints(metric.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		appendNumberPoints(metric.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			values = append(values, metric.Histogram().DataPoints().At(i).Sum())
+		}
+	}
+	return values
+}
+
+// checkHistogram validates that at least one histogram data point satisfies a histogram matcher
+func checkHistogram(matcher *contract.HistogramMatcher, metric pmetric.Metric) []mismatch {
+	if metric.Type() != pmetric.MetricTypeHistogram {
+		return []mismatch{{
+			field:    "histogram",
+			expected: "histogram",
+			actual:   contract.MetricTypeName(metric),
+			message:  fmt.Sprintf("histogram matcher requires a histogram metric, got %s", contract.MetricTypeName(metric)),
+		}}
+	}
+
+	points := metric.Histogram().DataPoints()
+	if points.Len() == 0 {
+		return []mismatch{{
+			field:   "histogram",
+			message: "histogram has no data points",
+		}}
+	}
+
+	var firstFailure string
+	for i := 0; i < points.Len(); i++ {
+		reason := histogramFailure(matcher, points.At(i))
+		if reason == "" {
+			return nil
+		}
+		if firstFailure == "" {
+			firstFailure = fmt.Sprintf("data point %d: %s", i, reason)
+		}
+	}
+
+	return []mismatch{{
+		field:   "histogram",
+		message: fmt.Sprintf("no histogram data point satisfied the histogram matcher: %s", firstFailure),
+	}}
+}
+
+// histogramFailure returns why a histogram data point fails a histogram matcher, or "" if it passes
+func histogramFailure(matcher *contract.HistogramMatcher, point pmetric.HistogramDataPoint) string {
+	bounds := point.ExplicitBounds().AsRaw()
+
+	if len(matcher.Buckets) > 0 {
+		if len(bounds) != len(matcher.Buckets) {
+			return fmt.Sprintf("bucket bounds mismatch: expected %v, got %v", matcher.Buckets, bounds)
+		}
+		for i := range bounds {
+			if bounds[i] != matcher.Buckets[i] {
+				return fmt.Sprintf("bucket bounds mismatch: expected %v, got %v", matcher.Buckets, bounds)
+			}
+		}
+	}
+
+	if matcher.Count != nil && point.Count() != uint64(*matcher.Count) {
+		return fmt.Sprintf("histogram count mismatch: expected %d, got %d", *matcher.Count, point.Count())
+	}
+
+	if matcher.Sum != nil && math.Abs(point.Sum()-*matcher.Sum) >= 1e-9 {
+		return fmt.Sprintf("histogram sum mismatch: expected %v, got %v", *matcher.Sum, point.Sum())
+	}
+
+	counts := point.BucketCounts().AsRaw()
+	for bound, expected := range matcher.BucketCounts {
+		index := -1
+		if math.IsInf(bound, 1) {
+			index = len(bounds)
+		}
+		for i, b := range bounds {
+			if b == bound {
+				index = i
+				break
+			}
+		}
+		if index < 0 || index >= len(counts) {
+			return fmt.Sprintf("bucket with upper bound %v not found", bound)
+		}
+		if counts[index] != uint64(expected) {
+			return fmt.Sprintf("bucket %v count mismatch: expected %d, got %d", bound, expected, counts[index])
+		}
+	}
+
+	return ""
+}
+
+// checkTimestamp validates a log record's timestamp, falling back to the observed timestamp
+func (m *Matcher) checkTimestamp(matcher *contract.TimestampMatcher, record plog.LogRecord) []mismatch {
+	timestamp := record.Timestamp()
+	if timestamp == 0 {
+		timestamp = record.ObservedTimestamp()
+	}
+	if timestamp == 0 {
+		return []mismatch{{
+			field:   "timestamp",
+			message: "log timestamp is not set",
+		}}
+	}
+	actual := timestamp.AsTime()
+
+	var mismatches []mismatch
+	fail := func(expected interface{}, message string) {
+		mismatches = append(mismatches, mismatch{
+			field:    "timestamp",
+			expected: expected,
+			actual:   actual.Format(time.RFC3339Nano),
+			message:  message,
+		})
+	}
+
+	if matcher.Range != nil {
+		if reason := timeRangeFailure(actual, *matcher.Range, matcher.Format); reason != "" {
+			fail(matcher.Range, reason)
+		}
+	}
+
+	if matcher.Relative != "" {
+		window, err := parseRelativeWindow(matcher.Relative)
+		if err != nil {
+			fail(matcher.Relative, err.Error())
+		} else {
+			now := time.Now()
+			if actual.Before(now.Add(-window)) || actual.After(now.Add(m.timeTolerance)) {
+				fail(matcher.Relative, fmt.Sprintf("timestamp %s is not %s", actual.Format(time.RFC3339Nano), matcher.Relative))
+			}
+		}
+	}
+
+	if matcher.Precision != "" {
+		var unit time.Duration
+		switch strings.ToLower(matcher.Precision) {
+		case "second":
+			unit = time.Second
+		case "millisecond":
+			unit = time.Millisecond
+		case "microsecond":
+			unit = time.Microsecond
+		case "nanosecond":
+			unit = time.Nanosecond
+		default:
+			fail(matcher.Precision, fmt.Sprintf("invalid timestamp precision %q", matcher.Precision))
+		}
+		if unit > 0 && actual.UnixNano()%int64(unit) != 0 {
+			fail(matcher.Precision, fmt.Sprintf("timestamp %s has finer than %s precision", actual.Format(time.RFC3339Nano), matcher.Precision))
+		}
+	}
+
+	return mismatches
+}
+
+// timeRangeFailure returns why a time falls outside a range, or "" if it is inside.
+// Bounds are interpreted according to format: rfc3339 (default), unix, unix_milli or unix_nano.
+func timeRangeFailure(actual time.Time, valueRange contract.ValueRange, format string) string {
+	if valueRange.Min != nil {
+		minTime, err := parseTimeBound(valueRange.Min, format)
+		if err != nil {
+			return fmt.Sprintf("invalid timestamp range min: %v", err)
+		}
+		minInclusive := valueRange.Inclusive
+		if valueRange.MinInclusive != nil {
+			minInclusive = *valueRange.MinInclusive
+		}
+		if actual.Before(minTime) || (!minInclusive && actual.Equal(minTime)) {
+			return fmt.Sprintf("timestamp %s is before range start %s", actual.Format(time.RFC3339Nano), minTime.Format(time.RFC3339Nano))
+		}
+	}
+
+	if valueRange.Max != nil {
+		maxTime, err := parseTimeBound(valueRange.Max, format)
+		if err != nil {
+			return fmt.Sprintf("invalid timestamp range max: %v", err)
+		}
+		maxInclusive := valueRange.Inclusive
+		if valueRange.MaxInclusive != nil {
+			maxInclusive = *valueRange.MaxInclusive
+		}
+		if actual.After(maxTime) || (!maxInclusive && actual.Equal(maxTime)) {
+			return fmt.Sprintf("timestamp %s is after range end %s", actual.Format(time.RFC3339Nano), maxTime.Format(time.RFC3339Nano))
+		}
+	}
+
+	return ""
+}
+
+// parseTimeBound converts a range bound into a time using the given format
+func parseTimeBound(bound interface{}, format string) (time.Time, error) {
+	if t, ok := bound.(time.Time); ok {
+		return t, nil
+	}
+
+	switch strings.ToLower(format) {
+	case "", "rfc3339":
+		s, ok := bound.(string)
+		if !ok {
+			return time.Time{}, fmt.Errorf("expected an RFC3339 string, got %v", bound)
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case "unix", "unix_milli", "unix_nano":
+		var n int64
+		switch v := bound.(type) {
+		case int:
+			n = int64(v)
+		case int64:
+			n = v
+		case string:
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			n = parsed
+		default:
+			return time.Time{}, fmt.Errorf("expected an integer %s timestamp, got %v", format, bound)
+		}
+		switch strings.ToLower(format) {
+		case "unix":
+			return time.Unix(n, 0), nil
+		case "unix_milli":
+			return time.UnixMilli(n), nil
+		default:
+			return time.Unix(0, n), nil
+		}
+	default:
+		return time.Time{}, fmt.Errorf("unsupported timestamp format %q", format)
+	}
+}
+
+// parseRelativeWindow parses relative windows such as within_last_hour or within_last_15m
+func parseRelativeWindow(relative string) (time.Duration, error) {
+	suffix, ok := strings.CutPrefix(relative, "within_last_")
+	if !ok {
+		return 0, fmt.Errorf("invalid relative timestamp %q, expected within_last_<unit|duration>", relative)
+	}
+
+	switch suffix {
+	case "second":
+		return time.Second, nil
+	case "minute":
+		return time.Minute, nil
+	case "hour":
+		return time.Hour, nil
+	case "day":
+		return 24 * time.Hour, nil
+	}
+
+	window, err := time.ParseDuration(suffix)
+	if err != nil {
+		return 0, fmt.Errorf("invalid relative timestamp %q: %w", relative, err)
+	}
+	return window, nil
+}
+
+// checkResource checks the resource an item was emitted under
+func (m *Matcher) checkResource(matcher *contract.ResourceMatcher, resource pcommon.Resource, schemaURL string) []mismatch {
+	mismatches := m.checkAttributes("resource.attributes", "resource attribute", matcher.Attributes, resource.Attributes())
+	if matcher.SchemaURL != "" && schemaURL != matcher.SchemaURL {
+		mismatches = append(mismatches, mismatch{
+			field:    "resource.schema_url",
+			expected: matcher.SchemaURL,
+			actual:   schemaURL,
+			message:  fmt.Sprintf("resource schema URL mismatch: expected %s, got %q", matcher.SchemaURL, schemaURL),
+		})
+	}
+	return mismatches
+}
+
+// checkScope checks the instrumentation scope an item was emitted under
+func (m *Matcher) checkScope(matcher *contract.ScopeMatcher, scope pcommon.InstrumentationScope, schemaURL string) []mismatch {
+	var mismatches []mismatch
+	if matcher.Name != "" && scope.Name() != matcher.Name {
+		mismatches = append(mismatches, mismatch{
+			field:    "scope.name",
+			expected: matcher.Name,
+			actual:   scope.Name(),
+			message:  fmt.Sprintf("scope name mismatch: expected %s, got %q", matcher.Name, scope.Name()),
+		})
+	}
+	if matcher.Version != "" && scope.Version() != matcher.Version {
+		mismatches = append(mismatches, mismatch{
+			field:    "scope.version",
+			expected: matcher.Version,
+			actual:   scope.Version(),
+			message:  fmt.Sprintf("scope version mismatch: expected %s, got %q", matcher.Version, scope.Version()),
+		})
+	}
+	mismatches = append(mismatches, m.checkAttributes("scope.attributes", "scope attribute", matcher.Attributes, scope.Attributes())...)
+	if matcher.SchemaURL != "" && schemaURL != matcher.SchemaURL {
+		mismatches = append(mismatches, mismatch{
+			field:    "scope.schema_url",
+			expected: matcher.SchemaURL,
+			actual:   schemaURL,
+			message:  fmt.Sprintf("scope schema URL mismatch: expected %s, got %q", matcher.SchemaURL, schemaURL),
+		})
+	}
+	return mismatches
+}
+
+// checkLabels checks the expected labels against each data point of a metric. The
+// metric matches when any data point has all of them; otherwise the data point with the
+// fewest mismatches is reported. It also returns the labels of the data point it chose.
+func (m *Matcher) checkLabels(expected map[string]interface{}, metric pmetric.Metric) ([]mismatch, pcommon.Map) {
+	points := dataPointAttributes(metric)
+	if len(points) == 0 {
+		labels := pcommon.NewMap()
+		return m.checkAttributes("labels", "label", expected, labels), labels
+	}
+
+	var closest []mismatch
+	closestLabels := points[0]
+	for i, labels := range points {
+		mismatches := m.checkAttributes("labels", "label", expected, labels)
+		if len(mismatches) == 0 {
+			return nil, labels
+		}
+		if i == 0 || len(mismatches) < len(closest) {
+			closest, closestLabels = mismatches, labels
+		}
+	}
+	return closest, closestLabels
+}
+
+// checkBody checks a log body against the body pattern and the expected values of
+// structured body fields. String bodies are parsed as JSON first when requested.
+func (m *Matcher) checkBody(matcher contract.LogMatcher, body pcommon.Value) []mismatch {
+	var mismatches []mismatch
+	if matcher.BodyMatches != "" {
+		pattern, err := regexp.Compile(matcher.BodyMatches)
+		if err != nil {
+			mismatches = append(mismatches, mismatch{
+				field:    "body",
+				expected: matcher.BodyMatches,
+				message:  fmt.Sprintf("invalid body pattern %q: %v", matcher.BodyMatches, err),
+			})
+		} else if !pattern.MatchString(body.AsString()) {
+			mismatches = append(mismatches, mismatch{
+				field:    "body",
+				expected: matcher.BodyMatches,
+				actual:   body.AsString(),
+				message:  fmt.Sprintf("log body %q does not match %s", body.AsString(), matcher.BodyMatches),
+			})
+		}
+	}
+	if len(matcher.BodyFields) == 0 {
+		return mismatches
+	}
+
+	root := body
+	if matcher.ParseJSON && body.Type() == pcommon.ValueTypeStr {
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(body.Str()), &parsed); err != nil {
+			return append(mismatches, mismatch{
+				field:    "body",
+				expected: "JSON",
+				actual:   body.Str(),
+				message:  fmt.Sprintf("log body is not valid JSON: %v", err),
+			})
+		}
+		root = pcommon.NewValueEmpty()
+		if err := root.FromRaw(parsed); err != nil {
+			return append(mismatches, mismatch{field: "body", message: fmt.Sprintf("log body is not valid JSON: %v", err)})
+		}
+	}
+
+	for _, key := range sortedKeys(matcher.BodyFields) {
+		fieldName := strings.TrimPrefix(key, "!")
+		path, err := contract.ParseFieldPath(fieldName)
+		if err != nil {
+			mismatches = append(mismatches, mismatch{field: "body." + fieldName, message: err.Error()})
+			continue
+		}
+		values := path.Evaluate(root)
+
+		if strings.HasPrefix(key, "!") {
+			// Negation - field should not exist
+			for _, value := range values {
+				if value != nil {
+					mismatches = append(mismatches, mismatch{
+						field:   "body." + fieldName,
+						actual:  value,
+						message: fmt.Sprintf("body field %s should not exist", fieldName),
+					})
+					break
+				}
+			}
+			continue
+		}
+
+		for i, value := range values {
+			name := "body field " + fieldName
+			if len(values) > 1 {
+				name += fmt.Sprintf(" (item %d)", i)
+			}
+			actual := pcommon.NewValueEmpty()
+			if value != nil {
+				if err := actual.FromRaw(value); err != nil {
+					actual.SetStr(fmt.Sprintf("%v", value))
+				}
+			}
+			if failure := m.compareAttribute("body."+fieldName, name, matcher.BodyFields[key], actual, value != nil); failure != nil {
+				mismatches = append(mismatches, *failure)
+			}
+		}
+	}
+	return mismatches
+}
+
+// checkSeverity checks a log record's severity text, number and number range. Records
+// without a severity number are ranked by their severity text.
+func checkSeverity(matcher contract.SeverityMatcher, record plog.LogRecord) []mismatch {
+	var mismatches []mismatch
+	if matcher.Text != "" && record.SeverityText() != matcher.Text {
+		mismatches = append(mismatches, mismatch{
+			field:    "severity",
+			expected: matcher.Text,
+			actual:   record.SeverityText(),
+			message:  fmt.Sprintf("log severity mismatch: expected %s, got %s", matcher.Text, record.SeverityText()),
+		})
+	}
+	if matcher.Number == 0 && matcher.Min == "" && matcher.Max == "" {
+		return mismatches
+	}
+
+	number := record.SeverityNumber()
+	if number == plog.SeverityNumberUnspecified {
+		if parsed, err := contract.ParseSeverityNumber(record.SeverityText()); err == nil {
+			number = parsed
+		}
+	}
+	actual := fmt.Sprintf("%s (%d)", number, number)
+
+	if matcher.Number != 0 && int32(number) != matcher.Number {
+		mismatches = append(mismatches, mismatch{
+			field:    "severity_number",
+			expected: matcher.Number,
+			actual:   int32(number),
+			message: fmt.Sprintf("log severity number mismatch: expected %s (%d), got %s",
+				plog.SeverityNumber(matcher.Number), matcher.Number, actual),
+		})
+	}
+
+	low, high, err := matcher.Range()
+	if err != nil {
+		return append(mismatches, mismatch{field: "severity_number", message: err.Error()})
+	}
+	switch {
+	case matcher.Min != "" && number < low:
+		mismatches = append(mismatches, mismatch{
+			field:    "severity_number",
+			expected: fmt.Sprintf(">= %s", matcher.Min),
+			actual:   int32(number),
+			message:  fmt.Sprintf("log severity %s is below minimum %s", actual, matcher.Min),
+		})
+	case matcher.Max != "" && number > high:
+		mismatches = append(mismatches, mismatch{
+			field:    "severity_number",
+			expected: fmt.Sprintf("<= %s", matcher.Max),
+			actual:   int32(number),
+			message:  fmt.Sprintf("log severity %s is above maximum %s", actual, matcher.Max),
+		})
+	}
+	return mismatches
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package matcher
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// spanNode is a span within a reconstructed trace tree
+type spanNode struct {
+	span     ptrace.Span
+	parent   *spanNode
+	children []*spanNode
+}
+
+// traceTree is the span tree of a single trace rebuilt from parent span IDs
+type traceTree struct {
+	traceID string
+	nodes   []*spanNode
+	roots   []*spanNode
+	orphans []*spanNode
+}
+
+// buildTraceTrees groups spans by trace ID and links each span to its parent.
+// Trees are returned in the order their first span appears in the output.
+func buildTraceTrees(traces ptrace.Traces) []*traceTree {
+	var trees []*traceTree
+	byTraceID := make(map[string]*traceTree)
+	for _, candidate := range collectSpans(traces) {
+		traceID := candidate.span.TraceID().String()
+		tree, ok := byTraceID[traceID]
+		if !ok {
+			tree = &traceTree{traceID: traceID}
+			byTraceID[traceID] = tree
+			trees = append(trees, tree)
+		}
+		tree.nodes = append(tree.nodes, &spanNode{span: candidate.span})
+	}
+
+	for _, tree := range trees {
+		bySpanID := make(map[string]*spanNode, len(tree.nodes))
+		for _, node := range tree.nodes {
+			bySpanID[node.span.SpanID().String()] = node
+		}
+		for _, node := range tree.nodes {
+			if node.span.ParentSpanID().IsEmpty() {
+				tree.roots = append(tree.roots, node)
+				continue
+			}
+			parent, ok := bySpanID[node.span.ParentSpanID().String()]
+			if !ok {
+				tree.orphans = append(tree.orphans, node)
+				continue
+			}
+			node.parent = parent
+			parent.children = append(parent.children, node)
+		}
+	}
+	return trees
+}
+
+// depth returns the number of spans on the longest path from a root or orphan to a leaf
+func (t *traceTree) depth() int {
+	var walk func(node *spanNode, visited map[*spanNode]bool) int
+	walk = func(node *spanNode, visited map[*spanNode]bool) int {
+		// Guard against parent cycles in malformed output
+		if visited[node] {
+			return 0
+		}
+		visited[node] = true
+		deepest := 0
+		for _, child := range node.children {
+			if d := walk(child, visited); d > deepest {
+				deepest = d
+			}
+		}
+		return deepest + 1
+	}
+
+	deepest := 0
+	for _, start := range append(append([]*spanNode{}, t.roots...), t.orphans...) {
+		if d := walk(start, make(map[*spanNode]bool)); d > deepest {
+			deepest = d
+		}
+	}
+	return deepest
+}
+
+// validateTraceStructure checks trace topology assertions against the span trees of the output
+func (m *Matcher) validateTraceStructure(matcher *contract.TraceStructureMatcher, traces ptrace.Traces) error {
+	trees := buildTraceTrees(traces)
+	var failures []error
+
+	if matcher.TraceCount != nil {
+		if failure := countFailure(matcher.TraceCount, len(trees)); failure != "" {
+			failures = append(failures, mismatch{field: "trace_count", actual: len(trees), message: "trace count: " + failure})
+		}
+	}
+
+	for _, tree := range trees {
+		if matcher.SpansPerTrace != nil {
+			if failure := countFailure(matcher.SpansPerTrace, len(tree.nodes)); failure != "" {
+				failures = append(failures, mismatch{
+					field:   "spans_per_trace",
+					actual:  len(tree.nodes),
+					message: fmt.Sprintf("trace %s span count: %s", tree.traceID, failure),
+				})
+			}
+		}
+
+		if matcher.RootSpan != "" {
+			root := mismatch{field: "root_span", expected: matcher.RootSpan}
+			switch len(tree.roots) {
+			case 0:
+				root.message = fmt.Sprintf("trace %s has no root span, expected %s", tree.traceID, matcher.RootSpan)
+			case 1:
+				if name := tree.roots[0].span.Name(); name != matcher.RootSpan {
+					root.actual = name
+					root.message = fmt.Sprintf("trace %s root span is %s, expected %s", tree.traceID, name, matcher.RootSpan)
+				}
+			default:
+				root.actual = nodeNames(tree.roots)
+				root.message = fmt.Sprintf("trace %s has %d root spans (%s), expected one named %s",
+					tree.traceID, len(tree.roots), nodeNames(tree.roots), matcher.RootSpan)
+			}
+			if root.message != "" {
+				failures = append(failures, root)
+			}
+		}
+
+		if !matcher.AllowOrphans {
+			for _, orphan := range tree.orphans {
+				failures = append(failures, mismatch{
+					field:   "allow_orphans",
+					actual:  orphan.span.Name(),
+					message: fmt.Sprintf("span %s in trace %s is orphaned: parent %s not found", orphan.span.Name(), tree.traceID, orphan.span.ParentSpanID()),
+				})
+			}
+		}
+
+		if matcher.MaxDepth > 0 {
+			if depth := tree.depth(); depth > matcher.MaxDepth {
+				failures = append(failures, mismatch{
+					field:    "max_depth",
+					expected: matcher.MaxDepth,
+					actual:   depth,
+					message:  fmt.Sprintf("trace %s has depth %d, exceeding max depth %d", tree.traceID, depth, matcher.MaxDepth),
+				})
+			}
+		}
+	}
+
+	for _, edge := range matcher.Edges {
+		if failure := edgeFailure(edge, trees); failure != "" {
+			failures = append(failures, mismatch{
+				field:    "edges",
+				expected: fmt.Sprintf("%s -> %s", edge.Parent, edge.Child),
+				message:  failure,
+			})
+		}
+	}
+
+	return errors.Join(failures...)
+}
+
+// edgeFailure describes why no span named edge.Child has a parent named edge.Parent
+func edgeFailure(edge contract.SpanEdge, trees []*traceTree) string {
+	var parents []string
+	for _, tree := range trees {
+		for _, node := range tree.nodes {
+			if node.span.Name() != edge.Child {
+				continue
+			}
+			switch {
+			case node.parent != nil && node.parent.span.Name() == edge.Parent:
+				return ""
+			case node.parent != nil:
+				parents = append(parents, node.parent.span.Name())
+			case node.span.ParentSpanID().IsEmpty():
+				parents = append(parents, "<root>")
+			default:
+				parents = append(parents, "<missing>")
+			}
+		}
+	}
+
+	if len(parents) == 0 {
+		return fmt.Sprintf("edge %s -> %s: no span named %s found", edge.Parent, edge.Child, edge.Child)
+	}
+	return fmt.Sprintf("edge %s -> %s: span %s has parent %s", edge.Parent, edge.Child, edge.Child, strings.Join(parents, ", "))
+}
+
+// nodeNames joins the span names of the given nodes
+func nodeNames(nodes []*spanNode) string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.span.Name())
+	}
+	return strings.Join(names, ", ")
+}
@@ -8,8 +8,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/goedelsoup/waveform/internal/harness"
 )
 
@@ -21,6 +23,8 @@ type JUnitTestSuite struct {
 	Failures  int             `xml:"failures,attr"`
 	Errors    int             `xml:"errors,attr"`
 	Skipped   int             `xml:"skipped,attr"`
+	Flaky     int             `xml:"flaky,attr,omitempty"`
+	NotRun    int             `xml:"notrun,attr,omitempty"`
 	Time      float64         `xml:"time,attr"`
 	Timestamp string          `xml:"timestamp,attr"`
 	TestCases []JUnitTestCase `xml:"testcase"`
@@ -32,8 +36,11 @@ type JUnitTestCase struct {
 	Name      string        `xml:"name,attr"`
 	Classname string        `xml:"classname,attr"`
 	Time      float64       `xml:"time,attr"`
+	Flaky     bool          `xml:"flaky,attr,omitempty"`
+	Attempts  int           `xml:"attempts,attr,omitempty"`
 	Failure   *JUnitFailure `xml:"failure,omitempty"`
 	Error     *JUnitError   `xml:"error,omitempty"`
+	Skipped   *JUnitSkipped `xml:"skipped,omitempty"`
 }
 
 // JUnitFailure represents a JUnit test failure
@@ -44,6 +51,12 @@ type JUnitFailure struct {
 	Content string   `xml:",chardata"`
 }
 
+// JUnitSkipped represents a JUnit skipped test
+type JUnitSkipped struct {
+	XMLName xml.Name `xml:"skipped"`
+	Message string   `xml:"message,attr,omitempty"`
+}
+
 // JUnitError represents a JUnit test error
 type JUnitError struct {
 	XMLName xml.Name `xml:"error"`
@@ -58,9 +71,13 @@ type LCOVRecord struct {
 	Publisher    string
 	Pipeline     string
 	Covered      bool
+	Skipped      bool
+	Flaky        bool
+	Attempts     int
 	Duration     time.Duration
 	ErrorCount   int
 	WarningCount int
+	Diff         string
 }
 
 // ReportGenerator generates test reports in various formats
@@ -89,7 +106,9 @@ func (r *ReportGenerator) GenerateJUnitXML(outputPath string) error {
 		Tests:     r.results.TotalTests,
 		Failures:  r.results.FailedTests,
 		Errors:    0, // We don't distinguish between failures and errors for now
-		Skipped:   0,
+		Skipped:   r.results.SkippedTests,
+		Flaky:     r.results.FlakyTests,
+		NotRun:    r.results.NotRunTests,
 		Time:      r.results.Duration.Seconds(),
 		Timestamp: time.Now().Format(time.RFC3339),
 		TestCases: make([]JUnitTestCase, 0, len(r.results.Results)),
@@ -101,9 +120,15 @@ func (r *ReportGenerator) GenerateJUnitXML(outputPath string) error {
 			Name:      fmt.Sprintf("%s/%s", result.Contract.Publisher, result.Contract.Pipeline),
 			Classname: result.Contract.Publisher,
 			Time:      result.Duration.Seconds(),
+			Flaky:     result.Flaky,
+		}
+		if result.Attempts > 1 {
+			testCase.Attempts = result.Attempts
 		}
 
-		if !result.Valid {
+		if result.Skipped {
+			testCase.Skipped = &JUnitSkipped{Message: result.SkipReason}
+		} else if !result.Valid {
 			// Create failure message
 			failureMessage := "Contract validation failed"
 			if len(result.Errors) > 0 {
@@ -113,7 +138,7 @@ func (r *ReportGenerator) GenerateJUnitXML(outputPath string) error {
 			testCase.Failure = &JUnitFailure{
 				Message: failureMessage,
 				Type:    "ValidationError",
-				Content: r.formatErrors(result.Errors),
+				Content: r.formatErrors(result.Errors) + r.formatFailures(result.Failures) + r.formatDiffs(result.Diffs, "  "),
 			}
 		}
 
@@ -152,10 +177,14 @@ func (r *ReportGenerator) GenerateLCOV(outputPath string) error {
 			TestName:     fmt.Sprintf("%s/%s", result.Contract.Publisher, result.Contract.Pipeline),
 			Publisher:    result.Contract.Publisher,
 			Pipeline:     result.Contract.Pipeline,
-			Covered:      result.Valid,
+			Covered:      result.Valid && !result.Skipped,
+			Skipped:      result.Skipped,
+			Flaky:        result.Flaky,
+			Attempts:     result.Attempts,
 			Duration:     result.Duration,
 			ErrorCount:   len(result.Errors),
 			WarningCount: len(result.Warnings),
+			Diff:         r.formatDiffs(result.Diffs, ""),
 		}
 		records = append(records, record)
 	}
@@ -203,29 +232,89 @@ func (r *ReportGenerator) formatErrors(errors []string) string {
 	return result
 }
 
+// formatFailures lists the field, expected and actual value of each matcher failure
+func (r *ReportGenerator) formatFailures(failures []contract.ValidationError) string {
+	var lines []string
+	for _, failure := range failures {
+		if failure.Field == "" {
+			continue
+		}
+		line := fmt.Sprintf("  - %s", failure.Type)
+		if failure.SignalType != "" {
+			line += fmt.Sprintf(" %s[%d]", failure.SignalType, failure.Index)
+		}
+		line += fmt.Sprintf(" %s:", failure.Field)
+		if failure.Expected != nil {
+			line += fmt.Sprintf(" expected %v", failure.Expected)
+		}
+		if failure.Actual != nil {
+			line += fmt.Sprintf(" actual %v", failure.Actual)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "Failures:\n" + strings.Join(lines, "\n") + "\n"
+}
+
+// formatDiffs renders the telemetry diffs of a failed test, one block per matcher
+func (r *ReportGenerator) formatDiffs(diffs []*contract.TelemetryDiff, indent string) string {
+	if len(diffs) == 0 {
+		return ""
+	}
+
+	result := indent + "Diff (- missing, + unexpected, ~ changed):\n"
+	for _, diff := range diffs {
+		result += diff.Render(indent + "  ")
+	}
+	return result
+}
+
 // generateLCOVContent generates LCOV format content
 func (r *ReportGenerator) generateLCOVContent(records []LCOVRecord) string {
 	content := "# LCOV coverage report for OpenTelemetry Contract Tests\n"
 	content += fmt.Sprintf("# Generated at: %s\n", time.Now().Format(time.RFC3339))
 	content += fmt.Sprintf("# Total tests: %d\n", len(records))
 
-	passed := 0
+	passed, skipped, flaky := 0, 0, 0
 	for _, record := range records {
-		if record.Covered {
+		if record.Skipped {
+			skipped++
+		} else if record.Covered {
 			passed++
 		}
+		if record.Flaky {
+			flaky++
+		}
 	}
 	content += fmt.Sprintf("# Passed tests: %d\n", passed)
-	content += fmt.Sprintf("# Failed tests: %d\n", len(records)-passed)
+	content += fmt.Sprintf("# Failed tests: %d\n", len(records)-passed-skipped)
+	content += fmt.Sprintf("# Skipped tests: %d\n", skipped)
+	content += fmt.Sprintf("# Flaky tests: %d\n", flaky)
+	if r.results.NotRunTests > 0 {
+		content += fmt.Sprintf("# Not run (fail-fast): %d\n", r.results.NotRunTests)
+	}
 	content += fmt.Sprintf("# Coverage: %.2f%%\n", float64(passed)/float64(len(records))*100)
 	content += "\n"
 
 	// Add test details
 	for _, record := range records {
 		status := "PASS"
-		if !record.Covered {
+		if record.Skipped {
+			status = "SKIP"
+		} else if !record.Covered {
 			status = "FAIL"
 		}
+		// LCOV has no field for failure details, so diffs and flakiness are written as comments
+		if record.Flaky {
+			content += fmt.Sprintf("# FLAKY: passed on attempt %d\n", record.Attempts)
+		}
+		for _, line := range strings.Split(strings.TrimRight(record.Diff, "\n"), "\n") {
+			if line != "" {
+				content += "# " + line + "\n"
+			}
+		}
 		content += fmt.Sprintf("TN:%s\n", record.TestName)
 		content += fmt.Sprintf("TF:%s\n", record.Publisher)
 		content += fmt.Sprintf("FNF:%s\n", record.Pipeline)
@@ -252,10 +341,16 @@ func (r *ReportGenerator) generateSummaryContent() string {
 	content += fmt.Sprintf("Total tests: %d\n", r.results.TotalTests)
 	content += fmt.Sprintf("Passed tests: %d\n", r.results.PassedTests)
 	content += fmt.Sprintf("Failed tests: %d\n", r.results.FailedTests)
+	content += fmt.Sprintf("Skipped tests: %d\n", r.results.SkippedTests)
+	content += fmt.Sprintf("Flaky tests: %d\n", r.results.FlakyTests)
+	if r.results.NotRunTests > 0 {
+		content += fmt.Sprintf("Not run (fail-fast): %d\n", r.results.NotRunTests)
+	}
 
-	if r.results.TotalTests > 0 {
-		passRate := float64(r.results.PassedTests) / float64(r.results.TotalTests) * 100
-		content += fmt.Sprintf("Pass rate: %.2f%%\n", passRate)
+	// The pass rate covers the contracts that ran, so skips do not lower it
+	if executed := r.results.PassedTests + r.results.FailedTests; executed > 0 {
+		passRate := float64(r.results.PassedTests) / float64(executed) * 100
+		content += fmt.Sprintf("Pass rate: %.2f%% of %d run\n", passRate, executed)
 	}
 
 	content += "\nTest Results:\n"
@@ -271,25 +366,32 @@ func (r *ReportGenerator) generateSummaryContent() string {
 		content += fmt.Sprintf("Publisher: %s\n", publisher)
 		content += fmt.Sprintf("  Tests: %d\n", len(results))
 
-		passed := 0
+		passed, skipped := 0, 0
 		for _, result := range results {
-			if result.Valid {
+			if result.Skipped {
+				skipped++
+			} else if result.Valid {
 				passed++
 			}
 		}
 		content += fmt.Sprintf("  Passed: %d\n", passed)
-		content += fmt.Sprintf("  Failed: %d\n", len(results)-passed)
+		content += fmt.Sprintf("  Failed: %d\n", len(results)-passed-skipped)
+		content += fmt.Sprintf("  Skipped: %d\n", skipped)
 
-		if len(results) > 0 {
-			passRate := float64(passed) / float64(len(results)) * 100
-			content += fmt.Sprintf("  Pass rate: %.2f%%\n", passRate)
+		if executed := len(results) - skipped; executed > 0 {
+			passRate := float64(passed) / float64(executed) * 100
+			content += fmt.Sprintf("  Pass rate: %.2f%% of %d run\n", passRate, executed)
 		}
 
 		content += "\n  Details:\n"
 		for _, result := range results {
 			status := "PASS"
-			if !result.Valid {
+			if result.Skipped {
+				status = "SKIP"
+			} else if !result.Valid {
 				status = "FAIL"
+			} else if result.Flaky {
+				status = fmt.Sprintf("FLAKY, passed on attempt %d", result.Attempts)
 			}
 			content += fmt.Sprintf("    %s/%s: %s (%s)\n",
 				result.Contract.Publisher,
@@ -297,8 +399,14 @@ func (r *ReportGenerator) generateSummaryContent() string {
 				status,
 				result.Duration)
 
-			if !result.Valid && len(result.Errors) > 0 {
-				content += fmt.Sprintf("      Error: %s\n", result.Errors[0])
+			if !result.Valid {
+				for _, err := range result.Errors {
+					content += fmt.Sprintf("      Error: %s\n", err)
+				}
+				content += r.formatDiffs(result.Diffs, "      ")
+			}
+			if result.Skipped && result.SkipReason != "" {
+				content += fmt.Sprintf("      Skipped: %s\n", result.SkipReason)
 			}
 		}
 		content += "\n"
@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package report
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goedelsoup/waveform/internal/contract"
+	"github.com/goedelsoup/waveform/internal/harness"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testResult returns a result for a contract of the given publisher and pipeline
+func testResult(publisher, pipeline string, valid bool) harness.TestResult {
+	return harness.TestResult{
+		Contract: &contract.Contract{Publisher: publisher, Pipeline: pipeline, Version: "1.0"},
+		Valid:    valid,
+		Duration: 10 * time.Millisecond,
+	}
+}
+
+// generateReports writes every report format and returns the JUnit suite, LCOV content and summary
+func generateReports(t *testing.T, results harness.TestResults) (JUnitTestSuite, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	generator := NewReportGenerator(results)
+
+	junitPath := filepath.Join(dir, "results.xml")
+	require.NoError(t, generator.GenerateJUnitXML(junitPath))
+	data, err := os.ReadFile(junitPath)
+	require.NoError(t, err)
+	var suite JUnitTestSuite
+	require.NoError(t, xml.Unmarshal(data, &suite))
+
+	lcovPath := filepath.Join(dir, "coverage.info")
+	require.NoError(t, generator.GenerateLCOV(lcovPath))
+	lcov, err := os.ReadFile(lcovPath)
+	require.NoError(t, err)
+
+	summaryPath := filepath.Join(dir, "summary.txt")
+	require.NoError(t, generator.GenerateSummary(summaryPath))
+	summary, err := os.ReadFile(summaryPath)
+	require.NoError(t, err)
+
+	return suite, string(lcov), string(summary)
+}
+
+func TestReport_SkippedContracts(t *testing.T) {
+	skipped := testResult("checkout", "traces", true)
+	skipped.Skipped = true
+	skipped.SkipReason = "filter environment equals production did not match"
+
+	results := harness.TestResults{
+		Results:      []harness.TestResult{testResult("checkout", "metrics", true), skipped},
+		TotalTests:   2,
+		PassedTests:  1,
+		SkippedTests: 1,
+	}
+	suite, lcov, summary := generateReports(t, results)
+
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Skipped)
+	require.Len(t, suite.TestCases, 2)
+	assert.Nil(t, suite.TestCases[0].Skipped)
+	require.NotNil(t, suite.TestCases[1].Skipped)
+	assert.Equal(t, "filter environment equals production did not match", suite.TestCases[1].Skipped.Message)
+	assert.Nil(t, suite.TestCases[1].Failure)
+
+	assert.Contains(t, lcov, "# Skipped tests: 1\n")
+	assert.Contains(t, lcov, "# Failed tests: 0\n")
+	assert.Contains(t, lcov, "TN:checkout/traces\nTF:checkout\nFNF:traces\nFNH:SKIP\n")
+
+	assert.Contains(t, summary, "Skipped tests: 1\n")
+	assert.Contains(t, summary, "  Skipped: 1\n")
+	// Skipped contracts do not lower the pass rate
+	assert.Contains(t, summary, "\nPass rate: 100.00% of 1 run\n")
+	assert.Contains(t, summary, "  Pass rate: 100.00% of 1 run\n")
+	assert.Contains(t, summary, "    checkout/traces: SKIP (10ms)\n      Skipped: filter environment equals production did not match\n")
+}
+
+func TestReport_Diffs(t *testing.T) {
+	failed := testResult("checkout", "traces", false)
+	failed.Errors = []string{"span GET /cart attribute http.method mismatch"}
+	failed.Diffs = []*contract.TelemetryDiff{{
+		SignalType: contract.SignalTypeTraces,
+		Matcher:    "trace matcher 0",
+		Location:   "span GET /cart",
+		Entries: []contract.DiffEntry{
+			{Section: contract.DiffSectionItem, Field: "name", Kind: contract.DiffUnchanged, Actual: `"GET /cart"`},
+			{Section: contract.DiffSectionAttribute, Field: "http.method", Kind: contract.DiffChanged, Expected: `"POST"`, Actual: `"GET"`},
+			{Section: contract.DiffSectionAttribute, Field: "user.id", Kind: contract.DiffRemoved, Expected: `"u-1"`},
+		},
+	}}
+
+	results := harness.TestResults{
+		Results:     []harness.TestResult{failed},
+		TotalTests:  1,
+		FailedTests: 1,
+	}
+	suite, lcov, summary := generateReports(t, results)
+
+	diff := "Diff (- missing, + unexpected, ~ changed):\n" +
+		"  trace matcher 0 vs span GET /cart:\n" +
+		"    item:\n" +
+		"        name: \"GET /cart\"\n" +
+		"    attribute:\n" +
+		"      ~ http.method: expected \"POST\", got \"GET\"\n" +
+		"      - user.id: expected \"u-1\"\n"
+
+	require.Len(t, suite.TestCases, 1)
+	require.NotNil(t, suite.TestCases[0].Failure)
+	assert.Contains(t, suite.TestCases[0].Failure.Content, indentLines(diff, "  "))
+
+	// LCOV carries the diff as comments ahead of the record
+	assert.Contains(t, lcov, "# Diff (- missing, + unexpected, ~ changed):\n#   trace matcher 0 vs span GET /cart:\n")
+	assert.Contains(t, lcov, "#       ~ http.method: expected \"POST\", got \"GET\"\n")
+	assert.Contains(t, lcov, "#       - user.id: expected \"u-1\"\nTN:checkout/traces\n")
+
+	assert.Contains(t, summary, indentLines(diff, "      "))
+}
+
+// indentLines prefixes every line of text with indent
+func indentLines(text, indent string) string {
+	lines := strings.SplitAfter(text, "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		if line != "" {
+			b.WriteString(indent + line)
+		}
+	}
+	return b.String()
+}
+
+func TestReport_FailedExpectationsOnSeparateLines(t *testing.T) {
+	failed := testResult("checkout", "traces", false)
+	failed.Failures = []contract.ValidationError{
+		{Type: "trace", Message: "span GET /cart attribute http.method mismatch: expected POST, got GET", Field: "attributes.http.method", Expected: "POST", Actual: "GET", SignalType: contract.SignalTypeTraces},
+		{Type: "trace", Message: "span GET /cart attribute user.id not found", Field: "attributes.user.id", Expected: "u-1", SignalType: contract.SignalTypeTraces},
+	}
+	for _, failure := range failed.Failures {
+		failed.Errors = append(failed.Errors, failure.Message)
+	}
+
+	results := harness.TestResults{
+		Results:     []harness.TestResult{failed},
+		TotalTests:  1,
+		FailedTests: 1,
+	}
+	suite, _, summary := generateReports(t, results)
+
+	require.NotNil(t, suite.TestCases[0].Failure)
+	assert.Equal(t, "span GET /cart attribute http.method mismatch: expected POST, got GET", suite.TestCases[0].Failure.Message)
+	assert.Contains(t, suite.TestCases[0].Failure.Content,
+		"Errors:\n"+
+			"  1. span GET /cart attribute http.method mismatch: expected POST, got GET\n"+
+			"  2. span GET /cart attribute user.id not found\n"+
+			"Failures:\n"+
+			"  - trace traces[0] attributes.http.method: expected POST actual GET\n"+
+			"  - trace traces[0] attributes.user.id: expected u-1\n")
+
+	assert.Contains(t, summary,
+		"    checkout/traces: FAIL (10ms)\n"+
+			"      Error: span GET /cart attribute http.method mismatch: expected POST, got GET\n"+
+			"      Error: span GET /cart attribute user.id not found\n")
+}
+
+func TestReport_FlakyAndNotRun(t *testing.T) {
+	flaky := testResult("checkout", "traces", true)
+	flaky.Flaky = true
+	flaky.Attempts = 3
+	stable := testResult("checkout", "metrics", true)
+	stable.Attempts = 1
+
+	results := harness.TestResults{
+		Results:     []harness.TestResult{flaky, stable},
+		TotalTests:  2,
+		PassedTests: 2,
+		FlakyTests:  1,
+		NotRunTests: 4,
+	}
+	suite, lcov, summary := generateReports(t, results)
+
+	assert.Equal(t, 1, suite.Flaky)
+	assert.Equal(t, 4, suite.NotRun)
+	require.Len(t, suite.TestCases, 2)
+	assert.True(t, suite.TestCases[0].Flaky)
+	assert.Equal(t, 3, suite.TestCases[0].Attempts)
+	assert.False(t, suite.TestCases[1].Flaky)
+	assert.Zero(t, suite.TestCases[1].Attempts)
+
+	assert.Contains(t, lcov, "# Flaky tests: 1\n")
+	assert.Contains(t, lcov, "# Not run (fail-fast): 4\n")
+	assert.Contains(t, lcov, "# FLAKY: passed on attempt 3\nTN:checkout/traces\n")
+	assert.Contains(t, lcov, "TN:checkout/traces\nTF:checkout\nFNF:traces\nFNH:PASS\n")
+
+	assert.Contains(t, summary, "Flaky tests: 1\n")
+	assert.Contains(t, summary, "Not run (fail-fast): 4\n")
+	assert.Contains(t, summary, "    checkout/traces: FLAKY, passed on attempt 3 (10ms)\n")
+	assert.Contains(t, summary, "    checkout/metrics: PASS (10ms)\n")
+
+	// The not-run line is left out when every contract ran
+	results.NotRunTests = 0
+	suite, lcov, summary = generateReports(t, results)
+	assert.Zero(t, suite.NotRun)
+	assert.NotContains(t, lcov, "Not run")
+	assert.NotContains(t, summary, "Not run")
+}
@@ -0,0 +1,312 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package semconv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Signal names used in violations
+const (
+	SignalTraces  = "traces"
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+)
+
+// Violation is a single departure from the semantic conventions
+type Violation struct {
+	Signal     string // traces, metrics or logs
+	Index      int    // Ordinal of the span, metric or log record within its signal, or of the resource for resource attributes
+	Location   string // Where the item was found in the output
+	Convention string // The span or metric convention that was violated, if any
+	Field      string
+	Expected   interface{}
+	Actual     interface{}
+	Message    string
+}
+
+// Options controls which conventions are enforced
+type Options struct {
+	Recommended bool // Also report missing recommended attributes
+}
+
+// checker collects violations while walking output telemetry
+type checker struct {
+	registry   *Registry
+	options    Options
+	aliases    map[string][]string // Deprecated names of each attribute, sorted
+	violations []Violation
+}
+
+// Check validates output telemetry against the registry. Deprecated attribute names,
+// enum values, metric units and instrument types are always checked; required
+// attributes are checked for spans and metrics that a convention applies to, and
+// recommended attributes when enabled.
+func (r *Registry) Check(traces ptrace.Traces, metrics pmetric.Metrics, logs plog.Logs, options Options) []Violation {
+	c := &checker{registry: r, options: options, aliases: make(map[string][]string)}
+	for name, replacement := range r.Deprecated {
+		if replacement != "" {
+			c.aliases[replacement] = append(c.aliases[replacement], name)
+		}
+	}
+	for _, names := range c.aliases {
+		sort.Strings(names)
+	}
+	c.checkTraces(traces)
+	c.checkMetrics(metrics)
+	c.checkLogs(logs)
+	return c.violations
+}
+
+func (c *checker) checkTraces(traces ptrace.Traces) {
+	index := 0
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		c.checkAttributes(SignalTraces, i, fmt.Sprintf("resource %d", i), fmt.Sprintf("resource %d", i),
+			"resource.attributes", resourceSpans.Resource().Attributes())
+
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			spans := resourceSpans.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				location := fmt.Sprintf("resource %d, scope %d, span %d", i, j, k)
+				item := "span " + span.Name()
+				c.checkAttributes(SignalTraces, index, location, item, "attributes", span.Attributes())
+
+				kind := strings.ToLower(span.Kind().String())
+				for _, convention := range c.registry.Spans {
+					if convention.Kind != kind || !c.identifies(convention.When, span.Attributes()) {
+						continue
+					}
+					c.checkPresence(SignalTraces, index, location, fmt.Sprintf("%s (%s)", item, convention.Name),
+						convention.Name, "required", convention.Required, []pcommon.Map{span.Attributes()})
+					if c.options.Recommended {
+						c.checkPresence(SignalTraces, index, location, fmt.Sprintf("%s (%s)", item, convention.Name),
+							convention.Name, "recommended", convention.Recommended, []pcommon.Map{span.Attributes()})
+					}
+				}
+				index++
+			}
+		}
+	}
+}
+
+func (c *checker) checkMetrics(metrics pmetric.Metrics) {
+	conventions := make(map[string]MetricConvention, len(c.registry.Metrics))
+	for _, convention := range c.registry.Metrics {
+		conventions[convention.Name] = convention
+	}
+
+	index := 0
+	for i := 0; i < metrics.ResourceMetrics().Len(); i++ {
+		resourceMetrics := metrics.ResourceMetrics().At(i)
+		c.checkAttributes(SignalMetrics, i, fmt.Sprintf("resource %d", i), fmt.Sprintf("resource %d", i),
+			"resource.attributes", resourceMetrics.Resource().Attributes())
+
+		for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+			metricSlice := resourceMetrics.ScopeMetrics().At(j).Metrics()
+			for k := 0; k < metricSlice.Len(); k++ {
+				metric := metricSlice.At(k)
+				location := fmt.Sprintf("resource %d, scope %d, metric %d", i, j, k)
+				item := "metric " + metric.Name()
+				points := dataPointAttributes(metric)
+				for _, attributes := range points {
+					c.checkAttributes(SignalMetrics, index, location, item, "labels", attributes)
+				}
+
+				if convention, ok := conventions[metric.Name()]; ok {
+					if instrument := instrumentName(metric); instrument != convention.Instrument {
+						c.add(Violation{
+							Signal: SignalMetrics, Index: index, Location: location, Convention: convention.Name,
+							Field: "type", Expected: convention.Instrument, Actual: instrument,
+							Message: fmt.Sprintf("%s: instrument is %s, expected %s", item, instrument, convention.Instrument),
+						})
+					}
+					if metric.Unit() != convention.Unit {
+						c.add(Violation{
+							Signal: SignalMetrics, Index: index, Location: location, Convention: convention.Name,
+							Field: "unit", Expected: convention.Unit, Actual: metric.Unit(),
+							Message: fmt.Sprintf("%s: unit is %q, expected %q", item, metric.Unit(), convention.Unit),
+						})
+					}
+					c.checkPresence(SignalMetrics, index, location, item, convention.Name, "required", convention.Required, points)
+					if c.options.Recommended {
+						c.checkPresence(SignalMetrics, index, location, item, convention.Name, "recommended", convention.Recommended, points)
+					}
+				}
+				index++
+			}
+		}
+	}
+}
+
+func (c *checker) checkLogs(logs plog.Logs) {
+	index := 0
+	for i := 0; i < logs.ResourceLogs().Len(); i++ {
+		resourceLogs := logs.ResourceLogs().At(i)
+		c.checkAttributes(SignalLogs, i, fmt.Sprintf("resource %d", i), fmt.Sprintf("resource %d", i),
+			"resource.attributes", resourceLogs.Resource().Attributes())
+
+		for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+			records := resourceLogs.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				location := fmt.Sprintf("resource %d, scope %d, log %d", i, j, k)
+				c.checkAttributes(SignalLogs, index, location, fmt.Sprintf("log record %d", index), "attributes", records.At(k).Attributes())
+				index++
+			}
+		}
+	}
+}
+
+// checkAttributes reports deprecated attribute names and values outside an enum
+func (c *checker) checkAttributes(signal string, index int, location, item, field string, attributes pcommon.Map) {
+	attributes.Range(func(key string, value pcommon.Value) bool {
+		if replacement, deprecated := c.registry.Deprecated[key]; deprecated {
+			violation := Violation{
+				Signal: signal, Index: index, Location: location,
+				Field: field + "." + key, Expected: replacement, Actual: key,
+				Message: fmt.Sprintf("%s: attribute %s is deprecated, use %s", item, key, replacement),
+			}
+			if replacement == "" {
+				violation.Expected = nil
+				violation.Message = fmt.Sprintf("%s: attribute %s is deprecated and has no replacement", item, key)
+			}
+			c.add(violation)
+		}
+
+		if allowed, ok := c.registry.Enums[key]; ok && value.Type() == pcommon.ValueTypeStr && !contains(allowed, value.Str()) {
+			c.add(Violation{
+				Signal: signal, Index: index, Location: location,
+				Field: field + "." + key, Expected: allowed, Actual: value.Str(),
+				Message: fmt.Sprintf("%s: attribute %s has value %q, expected one of %s", item, key, value.Str(), strings.Join(allowed, ", ")),
+			})
+		}
+		return true
+	})
+}
+
+// checkPresence reports attributes of a convention that are missing from any of the
+// attribute maps, noting when a deprecated name was used in their place
+func (c *checker) checkPresence(signal string, index int, location, item, convention, level string, keys []string, maps []pcommon.Map) {
+	for _, key := range keys {
+		missing := 0
+		var deprecatedName string
+		for _, attributes := range maps {
+			if _, ok := attributes.Get(key); ok {
+				continue
+			}
+			missing++
+			if name := c.deprecatedName(key, attributes); name != "" {
+				deprecatedName = name
+			}
+		}
+		if missing == 0 {
+			continue
+		}
+
+		message := fmt.Sprintf("%s: missing %s attribute %s", item, level, key)
+		if len(maps) > 1 {
+			message += fmt.Sprintf(" on %d of %d data points", missing, len(maps))
+		}
+		if deprecatedName != "" {
+			message += fmt.Sprintf(" (found deprecated %s)", deprecatedName)
+		}
+		field := "attributes." + key
+		if signal == SignalMetrics {
+			field = "labels." + key
+		}
+		c.add(Violation{
+			Signal: signal, Index: index, Location: location, Convention: convention,
+			Field: field, Expected: key, Message: message,
+		})
+	}
+}
+
+// identifies reports whether every identifying attribute is present, under its
+// current or a deprecated name
+func (c *checker) identifies(keys []string, attributes pcommon.Map) bool {
+	for _, key := range keys {
+		if _, ok := attributes.Get(key); !ok && c.deprecatedName(key, attributes) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// deprecatedName returns a deprecated name of key that is present in attributes
+func (c *checker) deprecatedName(key string, attributes pcommon.Map) string {
+	for _, name := range c.aliases[key] {
+		if _, ok := attributes.Get(name); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+func (c *checker) add(violation Violation) {
+	c.violations = append(c.violations, violation)
+}
+
+// instrumentName returns the semantic convention instrument of a metric
+func instrumentName(metric pmetric.Metric) string {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return "gauge"
+	case pmetric.MetricTypeSum:
+		if metric.Sum().IsMonotonic() {
+			return "counter"
+		}
+		return "updowncounter"
+	case pmetric.MetricTypeHistogram, pmetric.MetricTypeExponentialHistogram:
+		return "histogram"
+	case pmetric.MetricTypeSummary:
+		return "summary"
+	}
+	return ""
+}
+
+// dataPointAttributes returns the attributes of every data point of a metric
+func dataPointAttributes(metric pmetric.Metric) []pcommon.Map {
+	var attributes []pcommon.Map
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < metric.Gauge().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Gauge().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < metric.Sum().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Sum().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < metric.Histogram().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Histogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < metric.ExponentialHistogram().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.ExponentialHistogram().DataPoints().At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < metric.Summary().DataPoints().Len(); i++ {
+			attributes = append(attributes, metric.Summary().DataPoints().At(i).Attributes())
+		}
+	}
+	return attributes
+}
+
+// contains reports whether values holds value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
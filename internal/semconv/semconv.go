@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package semconv
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// data holds the bundled convention definitions, one file per version
+//
+//go:embed data/*.yaml
+var data embed.FS
+
+var (
+	registriesMu sync.Mutex
+	registries   = make(map[string]*Registry)
+)
+
+// Registry holds the conventions of a single semantic convention version
+type Registry struct {
+	Version    string              `yaml:"version"`
+	Enums      map[string][]string `yaml:"enums"`      // Allowed values of enum attributes
+	Deprecated map[string]string   `yaml:"deprecated"` // Deprecated attribute names and their replacement, empty when removed
+	Spans      []SpanConvention    `yaml:"spans"`
+	Metrics    []MetricConvention  `yaml:"metrics"`
+}
+
+// SpanConvention lists the attributes expected on spans of one kind of operation
+type SpanConvention struct {
+	Name        string   `yaml:"name"`
+	Kind        string   `yaml:"kind"` // Span kind the convention applies to
+	When        []string `yaml:"when"` // Attributes identifying spans the convention applies to
+	Required    []string `yaml:"required"`
+	Recommended []string `yaml:"recommended"`
+}
+
+// MetricConvention describes the instrument, unit and attributes of a named metric
+type MetricConvention struct {
+	Name        string   `yaml:"name"`
+	Instrument  string   `yaml:"instrument"` // counter, updowncounter, gauge or histogram
+	Unit        string   `yaml:"unit"`
+	Required    []string `yaml:"required"`
+	Recommended []string `yaml:"recommended"`
+}
+
+// Versions returns the bundled semantic convention versions
+func Versions() []string {
+	entries, err := data.ReadDir("data")
+	if err != nil {
+		return nil
+	}
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Load returns the bundled conventions of a version
+func Load(version string) (*Registry, error) {
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+
+	if registry, ok := registries[version]; ok {
+		return registry, nil
+	}
+
+	encoded, err := data.ReadFile(path.Join("data", version+".yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported semantic convention version %q (available: %s)", version, strings.Join(Versions(), ", "))
+	}
+	var registry Registry
+	if err := yaml.Unmarshal(encoded, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse semantic conventions %s: %w", version, err)
+	}
+	registries[version] = &registry
+	return &registry, nil
+}
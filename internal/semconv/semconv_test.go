@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+// SPDX-FileCopyrightText: © 2025 Cory Parent <goedelsoup+waveform@goedelsoup.io>
+
+package semconv
+
+import (
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestLoad(t *testing.T) {
+	registry, err := Load("1.26.0")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if registry.Version != "1.26.0" || len(registry.Spans) == 0 || len(registry.Metrics) == 0 {
+		t.Errorf("Expected bundled conventions to be loaded, got %+v", registry)
+	}
+
+	if _, err := Load("9.9.9"); err == nil || !strings.Contains(err.Error(), "available: 1.26.0") {
+		t.Errorf("Expected unsupported version error, got: %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	registry, err := Load("1.26.0")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	server := spans.AppendEmpty()
+	server.SetName("GET /users")
+	server.SetKind(ptrace.SpanKindServer)
+	server.Attributes().PutStr("http.method", "get")
+	server.Attributes().PutStr("url.path", "/users")
+	server.Attributes().PutStr("url.scheme", "https")
+	internal := spans.AppendEmpty()
+	internal.SetName("render")
+	internal.Attributes().PutStr("http.request.method", "GET")
+
+	metrics := pmetric.NewMetrics()
+	metric := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("http.server.request.duration")
+	metric.SetUnit("ms")
+	dp := metric.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("http.request.method", "TEAPOT")
+	dp.Attributes().PutStr("url.scheme", "http")
+
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Attributes().PutStr("db.user", "admin")
+
+	messages := func(options Options) []string {
+		var messages []string
+		for _, violation := range registry.Check(traces, metrics, logs, options) {
+			messages = append(messages, violation.Message)
+		}
+		return messages
+	}
+
+	got := messages(Options{})
+	want := []string{
+		"span GET /users: attribute http.method is deprecated, use http.request.method",
+		"span GET /users (http.server): missing required attribute http.request.method (found deprecated http.method)",
+		`metric http.server.request.duration: attribute http.request.method has value "TEAPOT", expected one of CONNECT, DELETE, GET, HEAD, OPTIONS, PATCH, POST, PUT, TRACE, _OTHER`,
+		"metric http.server.request.duration: instrument is gauge, expected histogram",
+		`metric http.server.request.duration: unit is "ms", expected "s"`,
+		"log record 0: attribute db.user is deprecated and has no replacement",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected violations:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	recommended := messages(Options{Recommended: true})
+	if len(recommended) <= len(got) {
+		t.Errorf("Expected recommended level to report more violations than required, got %d and %d", len(recommended), len(got))
+	}
+	found := false
+	for _, message := range recommended {
+		if message == "span GET /users (http.server): missing recommended attribute http.route" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected missing recommended http.route, got:\n%s", strings.Join(recommended, "\n"))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/goedelsoup/waveform/internal/collector"
 	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/goedelsoup/waveform/internal/harness"
 	"github.com/goedelsoup/waveform/internal/report"
@@ -19,10 +20,13 @@ type Framework struct {
 	logger  *zap.Logger
 }
 
-// NewFramework creates a new testing framework instance
+// NewFramework creates a new testing framework instance that runs contracts through the
+// same processor implementations as the CLI
 func NewFramework(mode harness.TestMode, config harness.CollectorConfig) *Framework {
+	testHarness := harness.NewTestHarness(mode, config)
+	testHarness.SetProcessorFactory(collector.NewProcessorFactory(zap.NewNop()))
 	return &Framework{
-		harness: harness.NewTestHarness(mode, config),
+		harness: testHarness,
 		logger:  zap.NewNop(),
 	}
 }
@@ -31,6 +35,7 @@ func NewFramework(mode harness.TestMode, config harness.CollectorConfig) *Framew
 func (f *Framework) SetLogger(logger *zap.Logger) {
 	f.logger = logger
 	f.harness.SetLogger(logger)
+	f.harness.SetProcessorFactory(collector.NewProcessorFactory(logger))
 }
 
 // RunTests runs tests for the given contracts
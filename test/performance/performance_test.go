@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/goedelsoup/waveform/internal/collector"
 	"github.com/goedelsoup/waveform/internal/contract"
 	"github.com/goedelsoup/waveform/internal/harness"
 	"github.com/stretchr/testify/assert"
@@ -119,6 +120,7 @@ func TestPerformance_TestHarness(t *testing.T) {
 
 			testHarness := harness.NewTestHarness(harness.TestModePipeline, config)
 			testHarness.SetLogger(createTestLogger())
+			testHarness.SetProcessorFactory(collector.NewProcessorFactory(createTestLogger()))
 
 			// Measure performance
 			start := time.Now()
@@ -158,6 +160,7 @@ func TestPerformance_MemoryUsage(t *testing.T) {
 
 		testHarness := harness.NewTestHarness(harness.TestModePipeline, config)
 		testHarness.SetLogger(createTestLogger())
+		testHarness.SetProcessorFactory(collector.NewProcessorFactory(createTestLogger()))
 
 		// Run tests and measure memory usage
 		start := time.Now()